@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"bdo_calc_go/internal/config"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/logger"
+)
+
+func main() {
+	cfg := config.Load()
+	logg := logger.New()
+
+	regionCode := flag.String("region", cfg.Region, "trade market region (kr, na, eu, ...)")
+	items := flag.String("items", "", "comma separated item ids (default: every collected item not backfilled yet)")
+	flag.Parse()
+
+	region, err := bdoapi.LookupRegion(*regionCode)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 종료 시그널 받으면 진행 중인 요청 취소
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := repo.Open(ctx, cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
+
+	opts := []bdoapi.Option{bdoapi.WithRegion(region), bdoapi.WithBaseURL(cfg.BaseURL)}
+	if cfg.SharedRateLimit > 0 {
+		// 레플리카 전체가 지역별로 나눠 쓰는 예산
+		opts = append(opts, bdoapi.WithSharedLimiter(repo.NewPGBudgetLimiter(pool, "bdoapi:"+region.Code, cfg.SharedRateLimit)))
+	}
+	client := bdoapi.NewClient(opts...)
+	backfiller := service.NewPriceBackfiller(client, repo.NewItemRepoPG(pool), logg)
+
+	if *items == "" {
+		n, err := backfiller.BackfillPending(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		logg.Infof("[%s] backfilled %d items", region.Code, n)
+		return
+	}
+	for _, s := range strings.Split(*items, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			log.Fatalf("invalid item id %q", s)
+		}
+		if err := backfiller.Backfill(ctx, id, ""); err != nil {
+			logg.Errorf("[%s] backfill %d: %v", region.Code, id, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi/cassette"
+)
+
+// 카세트 정리 도구
+//
+//	cassette redact -dir testdata/cassettes
+//	cassette rotate -dir testdata/cassettes -keep 3 -max-age 720h
+//	cassette list   -dir testdata/cassettes
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	cmd, args := os.Args[1], os.Args[2:]
+	fset := flag.NewFlagSet(cmd, flag.ExitOnError)
+	dir := fset.String("dir", "testdata/cassettes", "cassette directory")
+
+	switch cmd {
+	case "redact":
+		keepHost := fset.Bool("keep-host", false, "keep recorded host")
+		keepTime := fset.Bool("keep-time", false, "keep recorded_at timestamps")
+		fset.Parse(args)
+		opt := cassette.RedactOptions{Host: !*keepHost, RecordedAt: !*keepTime}
+		n := 0
+		err := cassette.Walk(*dir, func(file string, c *cassette.Cassette) error {
+			c.Redact(opt)
+			n++
+			return c.Save(file)
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("redacted %d cassettes\n", n)
+
+	case "rotate":
+		keep := fset.Int("keep", 3, "interactions to keep per cassette (0: all)")
+		maxAge := fset.Duration("max-age", 0, "drop interactions older than this (0: never)")
+		fset.Parse(args)
+		now := time.Now()
+		removed, deleted := 0, 0
+		err := cassette.Walk(*dir, func(file string, c *cassette.Cassette) error {
+			n := c.Rotate(*keep, *maxAge, now)
+			if n == 0 {
+				return nil
+			}
+			removed += n
+			if len(c.Interactions) == 0 {
+				deleted++
+				return os.Remove(file)
+			}
+			return c.Save(file)
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("removed %d interactions, deleted %d cassettes\n", removed, deleted)
+
+	case "list":
+		fset.Parse(args)
+		err := cassette.Walk(*dir, func(file string, c *cassette.Cassette) error {
+			last := "-"
+			if n := len(c.Interactions); n > 0 && !c.Interactions[n-1].RecordedAt.IsZero() {
+				last = c.Interactions[n-1].RecordedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%-6s %-28s %-40s %3d  %s\n", c.Scope, c.Endpoint, c.Key, len(c.Interactions), last)
+			return nil
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: cassette <redact|rotate|list> [-dir dir] [flags]")
+	os.Exit(2)
+}
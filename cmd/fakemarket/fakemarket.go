@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+	"bdo_calc_go/pkg/logger"
+)
+
+// 오프라인용 가짜 거래소
+// ex) fakemarket -addr :8089 -tick 10s
+//
+//	BDO_BASE_URL=http://localhost:8089/Trademarket/ go run ./cmd/job -once
+func main() {
+	logg := logger.New()
+
+	addr := flag.String("addr", ":8089", "listen address")
+	statePath := flag.String("state", "", "market state json (empty: demo item groups)")
+	latency := flag.Duration("latency", 0, "delay added to every response")
+	tick := flag.Duration("tick", 0, "simulate random trades/price moves at this interval (0: static)")
+	seed := flag.Int64("seed", 1, "random seed for -tick")
+	flag.Parse()
+
+	m := fakemarket.Demo()
+	if *statePath != "" {
+		f, err := os.Open(*statePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		m, err = fakemarket.Load(f)
+		f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	m.SetLatency(*latency)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *tick > 0 {
+		go func() {
+			rng := rand.New(rand.NewSource(*seed))
+			t := time.NewTicker(*tick)
+			defer t.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-t.C:
+					m.Step(rng, now)
+				}
+			}
+		}()
+	}
+
+	srv := &http.Server{Addr: *addr, Handler: fakemarket.NewServer(m)}
+	go func() {
+		<-ctx.Done()
+		srv.Shutdown(context.Background())
+	}()
+	logg.Infof("fake trade market at %s/Trademarket/ (%d items)", *addr, len(m.State().Items))
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
@@ -2,14 +2,36 @@ package main
 
 import (
 	"bdo_calc_go/pkg/bdoapi"
+	"context"
+	"flag"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
-	minSale, maxBuy, err := bdoapi.GetBiddingInfoList(15720, 0)
+	regionCode := flag.String("region", bdoapi.DefaultRegion, "trade market region (kr, na, eu, ...)")
+	baseURL := flag.String("base-url", os.Getenv("BDO_BASE_URL"), "override trade market url (ex. cmd/fakemarket)")
+	itemID := flag.Int("item", 15720, "item id (mainKey)")
+	grade := flag.Int("grade", 0, "enhancement level (subKey)")
+	flag.Parse()
+
+	region, err := bdoapi.LookupRegion(*regionCode)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	client := bdoapi.NewClient(bdoapi.WithRegion(region), bdoapi.WithBaseURL(*baseURL))
+
+	// 종료 시그널 받으면 진행 중인 요청 취소
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	minSale, maxBuy, err := client.GetBiddingInfoList(ctx, *itemID, *grade)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
-	fmt.Printf("minSale: %d, maxBuy: %d\n", minSale, maxBuy)
+	fmt.Printf("[%s] minSale: %d, maxBuy: %d\n", region.Code, minSale, maxBuy)
 }
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"bdo_calc_go/internal/config"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/logger"
+)
+
+func main() {
+	cfg := config.Load()
+	logg := logger.New()
+
+	regionCode := flag.String("region", cfg.Region, "trade market region (kr, na, eu, ...)")
+	// 서버의 hot 피드도 같은 간격(BDO_HOT_LIST_INTERVAL)으로 연속 구간을 판단하므로 flag보다 env로 맞출 것
+	interval := flag.Duration("interval", cfg.HotListInterval, "poll interval")
+	once := flag.Bool("once", false, "poll once and exit")
+	flag.Parse()
+
+	region, err := bdoapi.LookupRegion(*regionCode)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 종료 시그널 받으면 진행 중인 요청 취소
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := repo.Open(ctx, cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
+
+	opts := []bdoapi.Option{bdoapi.WithRegion(region), bdoapi.WithBaseURL(cfg.BaseURL)}
+	if cfg.SharedRateLimit > 0 {
+		// 레플리카 전체가 지역별로 나눠 쓰는 예산
+		opts = append(opts, bdoapi.WithSharedLimiter(repo.NewPGBudgetLimiter(pool, "bdoapi:"+region.Code, cfg.SharedRateLimit)))
+	}
+	client := bdoapi.NewClient(opts...)
+	tracker := service.NewHotListTracker(client, repo.NewHotListRepoPG(pool), logg)
+
+	for {
+		now := time.Now()
+		n, err := tracker.Poll(ctx, now)
+		if err != nil {
+			logg.Errorf("[%s] hot list: %v", region.Code, err)
+		} else {
+			logg.Infof("[%s] hot list: %d entries", region.Code, n)
+		}
+		if pruned, err := tracker.Prune(ctx, now); err != nil {
+			logg.Errorf("[%s] hot list prune: %v", region.Code, err)
+		} else if pruned > 0 {
+			logg.Infof("[%s] hot list: pruned %d old entries", region.Code, pruned)
+		}
+		if *once {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(*interval):
+		}
+	}
+}
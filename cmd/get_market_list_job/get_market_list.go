@@ -2,15 +2,36 @@ package main
 
 import (
 	"bdo_calc_go/pkg/bdoapi"
+	"context"
+	"flag"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
-	list, err := bdoapi.GetMarketList("ore")
+	regionCode := flag.String("region", bdoapi.DefaultRegion, "trade market region (kr, na, eu, ...)")
+	baseURL := flag.String("base-url", os.Getenv("BDO_BASE_URL"), "override trade market url (ex. cmd/fakemarket)")
+	category := flag.String("category", "ore", "category name or id (ex. ore, material.ore, 25-1)")
+	flag.Parse()
+
+	region, err := bdoapi.LookupRegion(*regionCode)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	client := bdoapi.NewClient(bdoapi.WithRegion(region), bdoapi.WithBaseURL(*baseURL))
+
+	// 종료 시그널 받으면 진행 중인 요청 취소
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	list, err := client.GetMarketList(ctx, *category)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
-	fmt.Printf("parsed %d items\n", len(list))
+	fmt.Printf("[%s] parsed %d items\n", region.Code, len(list))
 	fmt.Printf("%+v\n", list[0]) // 첫 아이템 출력 예시
 }
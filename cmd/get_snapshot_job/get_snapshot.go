@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bdo_calc_go/pkg/bdoapi"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func main() {
+	regionCode := flag.String("region", bdoapi.DefaultRegion, "trade market region (kr, na, eu, ...)")
+	baseURL := flag.String("base-url", os.Getenv("BDO_BASE_URL"), "override trade market url (ex. cmd/fakemarket)")
+	categories := flag.String("categories", "all", "comma separated categories (ex. material,35-1,food)")
+	items := flag.String("items", "", "comma separated item ids to fetch details for")
+	minTrades := flag.Int64("min-trades", 0, "also fetch details for listed items with at least this many total trades (0: off)")
+	subLists := flag.Bool("sub-list", true, "fetch sub lists of selected items")
+	orderBooks := flag.Bool("order-book", true, "fetch order books of selected items")
+	workers := flag.Int("workers", bdoapi.DefaultSnapshotWorkers, "concurrent requests")
+	timeout := flag.Duration("timeout", bdoapi.DefaultSnapshotTimeout, "give up on remaining requests after this")
+	asJSON := flag.Bool("json", false, "print the whole snapshot as json")
+	flag.Parse()
+
+	region, err := bdoapi.LookupRegion(*regionCode)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	cats, err := bdoapi.Categories().Select(strings.Split(*categories, ",")...)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	opts := bdoapi.SnapshotOptions{Categories: cats, SubLists: *subLists, OrderBooks: *orderBooks, Workers: *workers, Timeout: *timeout}
+	for _, f := range strings.Split(*items, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			fmt.Printf("item id %q: %v\n", f, err)
+			return
+		}
+		opts.Items = append(opts.Items, id)
+	}
+	if *minTrades > 0 {
+		opts.Select = func(_ bdoapi.Category, o bdoapi.MarketListObject) bool { return o.TotalTrades >= *minTrades }
+	}
+	client := bdoapi.NewClient(bdoapi.WithRegion(region), bdoapi.WithBaseURL(*baseURL))
+
+	// 종료 시그널 받으면 진행 중인 요청 취소
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	snap, err := client.Snapshot(ctx, opts)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(snap)
+		return
+	}
+	listed := 0
+	for _, list := range snap.Lists {
+		listed += len(list)
+	}
+	errs := snap.Errors()
+	fmt.Printf("[%s] %s: %d categories, %d listed, %d detailed, %d errors in %s\n",
+		region.Code, snap.At.Format("2006-01-02 15:04:05"), len(snap.Lists), listed, len(snap.Items), len(errs), snap.Elapsed.Round(time.Millisecond))
+	for _, e := range errs {
+		fmt.Println(" ", e)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"bdo_calc_go/internal/config"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/logger"
+)
+
+func main() {
+	cfg := config.Load()
+	logg := logger.New()
+
+	regionCode := flag.String("region", cfg.Region, "trade market region (kr, na, eu, ...)")
+	interval := flag.Duration("interval", time.Minute, "poll interval")
+	once := flag.Bool("once", false, "poll once and exit")
+	flag.Parse()
+
+	region, err := bdoapi.LookupRegion(*regionCode)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 종료 시그널 받으면 진행 중인 요청 취소
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := repo.Open(ctx, cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
+
+	opts := []bdoapi.Option{bdoapi.WithRegion(region), bdoapi.WithBaseURL(cfg.BaseURL)}
+	if cfg.SharedRateLimit > 0 {
+		// 레플리카 전체가 지역별로 나눠 쓰는 예산
+		opts = append(opts, bdoapi.WithSharedLimiter(repo.NewPGBudgetLimiter(pool, "bdoapi:"+region.Code, cfg.SharedRateLimit)))
+	}
+	client := bdoapi.NewClient(opts...)
+	tracker := service.NewWaitListTracker(client, repo.NewWaitListRepoPG(pool), logg)
+
+	for {
+		now := time.Now()
+		n, err := tracker.Poll(ctx, now)
+		if err != nil {
+			logg.Errorf("[%s] wait list: %v", region.Code, err)
+		} else {
+			logg.Infof("[%s] wait list: %d entries", region.Code, n)
+		}
+		if *once {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(*interval):
+		}
+	}
+}
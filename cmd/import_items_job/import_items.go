@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"bdo_calc_go/internal/config"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/itemcatalog"
+	"bdo_calc_go/pkg/logger"
+)
+
+// 아이템 덤프(json/csv)를 items.item_attrs로 가져오기
+// ex) import_items_job -file items.csv -regions kr,na
+func main() {
+	cfg := config.Load()
+	logg := logger.New()
+
+	file := flag.String("file", "", "item dump file (.json or .csv)")
+	regionCodes := flag.String("regions", cfg.Region, "comma separated regions to import into, or all")
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("-file is required")
+	}
+
+	var regions []bdoapi.Region
+	if *regionCodes == "all" {
+		regions = bdoapi.Regions()
+	} else {
+		for _, code := range strings.Split(*regionCodes, ",") {
+			r, err := bdoapi.LookupRegion(code)
+			if err != nil {
+				log.Fatal(err)
+			}
+			regions = append(regions, r)
+		}
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	items, err := itemcatalog.Load(f, filepath.Ext(*file))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := repo.Open(ctx, cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
+
+	svc := service.NewCatalogService(repo.NewCatalogRepoPG(pool), logg)
+	if err := svc.Import(ctx, items, regions); err != nil {
+		log.Fatal(err)
+	}
+	logg.Infof("imported %d items into %d regions", len(items), len(regions))
+}
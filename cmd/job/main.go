@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"bdo_calc_go/internal/config"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/logger"
+)
+
+func main() {
+	cfg := config.Load()
+	logg := logger.New()
+
+	regionCode := flag.String("region", cfg.Region, "trade market region (kr, na, eu, ...)")
+	interval := flag.Duration("interval", 2*time.Minute, "collect interval")
+	categories := flag.String("categories", "material,consumable", "comma separated category names or ids (ex. material,35-1,food / all)")
+	once := flag.Bool("once", false, "run a single cycle and exit")
+	enhance := flag.String("enhance", string(service.EnhanceOff), "per enhancement level order books/series: off, gear (enhanceable categories), all (also any item with several levels)")
+	workers := flag.Int("workers", service.DefaultCollectWorkers, "items collected concurrently (requests still follow the client rate limit)")
+	parseMode := flag.String("parse-mode", cfg.ParseMode, "response parsing: strict (fail on bad records) or lenient (skip and report)")
+	minStock := flag.Int64("substitute-min-stock", service.DefaultSubstituteMinStock, "item group substitute: minimum stock to be eligible")
+	priceSource := flag.String("substitute-price", string(service.PriceSellBid), "item group substitute: price to compare (sell_bid, buy_bid, last_trade)")
+	flag.Parse()
+
+	region, err := bdoapi.LookupRegion(*regionCode)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 종료 시그널 받으면 진행 중인 사이클 취소
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := repo.Open(ctx, cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
+
+	source, err := service.ParsePriceSource(*priceSource)
+	if err != nil {
+		log.Fatal(err)
+	}
+	mode, err := bdoapi.LookupParseMode(*parseMode)
+	if err != nil {
+		log.Fatal(err)
+	}
+	enhanceMode, err := service.ParseEnhanceMode(*enhance)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cats, err := bdoapi.Categories().Select(strings.Split(*categories, ",")...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 포맷 변경(패치) 감지용
+	drift := bdoapi.NewDriftMonitor(logg.Errorf)
+	opts := []bdoapi.Option{bdoapi.WithRegion(region), bdoapi.WithBaseURL(cfg.BaseURL), bdoapi.WithParseMode(mode), bdoapi.WithDriftReporter(drift)}
+	if cfg.SharedRateLimit > 0 {
+		// 레플리카 전체가 지역별로 나눠 쓰는 예산
+		opts = append(opts, bdoapi.WithSharedLimiter(repo.NewPGBudgetLimiter(pool, "bdoapi:"+region.Code, cfg.SharedRateLimit)))
+	}
+	client := bdoapi.NewClient(opts...)
+	itemRepo := repo.NewItemRepoPG(pool)
+	collector := service.NewMarketCollector(client, itemRepo, logg, cats, enhanceMode, *workers)
+	catalog := service.NewCatalogService(repo.NewCatalogRepoPG(pool), logg)
+	if err := catalog.Reload(ctx); err != nil {
+		// 카탈로그가 없으면 대체 아이템 이름은 items row 것을 씀
+		logg.Errorf("item catalog: %v", err)
+	}
+	selector := service.NewSubstituteSelector(itemRepo, repo.NewSubstituteRepoPG(pool), catalog, logg, *minStock, source)
+
+	for {
+		start := time.Now().Truncate(time.Minute)
+		if err := collector.RunCycle(ctx, start); err != nil {
+			logg.Errorf("[%s] cycle failed: %v", region.Code, err)
+		} else if _, err := selector.Run(ctx, region.Code, start); err != nil {
+			logg.Errorf("[%s] item group substitutes: %v", region.Code, err)
+		}
+		logg.Infof("[%s] cycle done in %s", region.Code, time.Since(start).Round(time.Second))
+		if *once {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(start.Add(*interval))):
+		}
+	}
+}
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"context"
+	"expvar"
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -10,6 +14,8 @@ import (
 	"bdo_calc_go/internal/repo"
 	"bdo_calc_go/internal/router"
 	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/rediscache"
 	"bdo_calc_go/pkg/logger"
 )
 
@@ -17,21 +23,105 @@ func main() {
 	// 설정/로거 초기화
 	cfg := config.Load()
 	logg := logger.New()
+	// region 쿼리가 없는 요청의 기본 지역
+	region, err := bdoapi.LookupRegion(cfg.Region)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// 의존성 생성
 	userRepo := repo.NewUserRepoInMemory()
 	userSvc := service.NewUserService(userRepo, logg)
 	userH := handler.NewUserHandler(userSvc)
 
+	pool, err := repo.Open(context.Background(), cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
+	itemRepo := repo.NewItemRepoPG(pool)
+	itemSvc := service.NewItemService(itemRepo, logg)
+	itemH := handler.NewItemHandler(itemSvc, region.Code)
+	catalogSvc := service.NewCatalogService(repo.NewCatalogRepoPG(pool), logg)
+	if err := catalogSvc.Reload(context.Background()); err != nil {
+		// 카탈로그 없이도 나머지 API는 동작
+		logg.Errorf("item catalog: %v", err)
+	}
+	catalogH := handler.NewCatalogHandler(catalogSvc)
+	parseMode, err := bdoapi.LookupParseMode(cfg.ParseMode)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// 포맷 변경 카운터는 /debug/vars 의 bdoapi_drift
+	drift := bdoapi.NewDriftMonitor(logg.Errorf)
+	expvar.Publish("bdoapi_drift", drift)
+	marketOpts := []bdoapi.Option{bdoapi.WithBaseURL(cfg.BaseURL), bdoapi.WithParseMode(parseMode), bdoapi.WithDriftReporter(drift)}
+	cache, err := openCache(cfg.Cache)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cache != nil {
+		marketOpts = append(marketOpts, bdoapi.WithCache(cache))
+	}
+	marketSvc := service.NewMarketService(logg, marketOpts...)
+	// 캐시 적중/합쳐진 요청 수는 /debug/vars 의 bdoapi_cache
+	expvar.Publish("bdoapi_cache", expvar.Func(func() any { return marketSvc.CacheStats() }))
+	marketH := handler.NewMarketHandler(marketSvc, catalogSvc, region.Code)
+	waitListSvc := service.NewWaitListService(repo.NewWaitListRepoPG(pool))
+	waitListH := handler.NewWaitListHandler(waitListSvc, region.Code)
+	hotListSvc := service.NewHotListService(repo.NewHotListRepoPG(pool), cfg.HotListInterval)
+	hotListH := handler.NewHotListHandler(hotListSvc, region.Code)
+	substituteSvc := service.NewSubstituteService(repo.NewSubstituteRepoPG(pool))
+	substituteH := handler.NewSubstituteHandler(substituteSvc, region.Code)
+
 	// Gin 라우터 생성 및 라우팅 구성
 	r := gin.Default()
 	router.Register(r, router.Dependencies{
-		UserHandler: userH,
+		UserHandler:       userH,
+		ItemHandler:       itemH,
+		MarketHandler:     marketH,
+		WaitListHandler:   waitListH,
+		HotListHandler:    hotListH,
+		CatalogHandler:    catalogH,
+		SubstituteHandler: substituteH,
 	})
 
+	// 카운터(/debug/vars)는 공개 포트와 분리
+	if cfg.AdminAddr != "off" {
+		admin := gin.New()
+		admin.Use(gin.Recovery())
+		router.RegisterAdmin(admin)
+		go func() {
+			log.Printf("starting admin server at %s\n", cfg.AdminAddr)
+			if err := admin.Run(cfg.AdminAddr); err != nil {
+				logg.Errorf("admin server: %v", err)
+			}
+		}()
+	}
+
 	addr := ":" + cfg.Port
 	log.Printf("starting server at %s\n", addr)
 	if err := r.Run(addr); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// BDO_CACHE → 캐시 백엔드, off면 nil
+func openCache(spec string) (bdoapi.Cache, error) {
+	switch {
+	case spec == "off":
+		return nil, nil
+	case spec == "memory":
+		return bdoapi.NewMemoryCache(0), nil
+	case strings.HasPrefix(spec, "redis://"), strings.HasPrefix(spec, "rediss://"):
+		rc, err := rediscache.New(spec, rediscache.WithKeyPrefix("bdo_calc:"))
+		if err != nil {
+			return nil, err
+		}
+		if err := rc.Ping(context.Background()); err != nil {
+			return nil, fmt.Errorf("BDO_CACHE: %w", err)
+		}
+		return rc, nil
+	}
+	return nil, fmt.Errorf("BDO_CACHE: unknown cache %q (off, memory, redis://..., rediss://...)", spec)
+}
@@ -2,10 +2,22 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
-	Port string
+	Port        string
+	AdminAddr   string // /debug/vars 등 내부 전용 리스너, off면 안 띄움
+	DatabaseURL string
+	Region      string // 기본 거래소 지역
+	ParseMode   string // 응답 파싱 모드 (strict, lenient)
+	BaseURL     string // 거래소 주소 덮어쓰기 (cmd/fakemarket 등), 비어 있으면 지역 기본값
+	Cache       string // 거래소 응답 캐시: off(기본), memory, redis://host:6379/0, rediss://...
+	// 수집 job 레플리카끼리 나눠 쓰는 지역별 초당 요청 수 (DB api_rate_budget), 0이면 안 씀
+	SharedRateLimit int
+	// get_hot_list_job 폴링 간격, 서버는 hot 피드의 연속 구간 판단에 씀
+	HotListInterval time.Duration
 }
 
 func Load() *Config {
@@ -13,5 +25,40 @@ func Load() *Config {
 	if port == "" {
 		port = "8080"
 	}
-	return &Config{Port: port}
+	region := os.Getenv("BDO_REGION")
+	if region == "" {
+		region = "kr"
+	}
+	// 기본은 루프백만, 외부에서 보려면 ADMIN_ADDR=:6060 처럼 명시
+	adminAddr := os.Getenv("ADMIN_ADDR")
+	if adminAddr == "" {
+		adminAddr = "127.0.0.1:6060"
+	}
+	parseMode := os.Getenv("BDO_PARSE_MODE")
+	if parseMode == "" {
+		parseMode = "strict"
+	}
+	// 캐시는 켤 때만 (오래된 값을 돌려줄 수 있으므로)
+	cache := os.Getenv("BDO_CACHE")
+	if cache == "" {
+		cache = "off"
+	}
+	// 숫자가 아니면 0 (공유 예산 없음)
+	sharedRate, _ := strconv.Atoi(os.Getenv("BDO_SHARED_RATE_LIMIT"))
+	hotInterval, err := time.ParseDuration(os.Getenv("BDO_HOT_LIST_INTERVAL"))
+	if err != nil || hotInterval <= 0 {
+		hotInterval = 10 * time.Minute
+	}
+	return &Config{
+		Port:        port,
+		AdminAddr:   adminAddr,
+		DatabaseURL: os.Getenv("DATABASE_URL"),
+		Region:      region,
+		ParseMode:   parseMode,
+		BaseURL:     os.Getenv("BDO_BASE_URL"),
+		Cache:       cache,
+
+		SharedRateLimit: sharedRate,
+		HotListInterval: hotInterval,
+	}
 }
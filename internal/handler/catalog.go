@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/itemcatalog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CatalogHandler struct {
+	svc *service.CatalogService
+}
+
+func NewCatalogHandler(s *service.CatalogService) *CatalogHandler {
+	return &CatalogHandler{svc: s}
+}
+
+// GET /api/v1/catalog/:id
+func (h *CatalogHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item id"})
+		return
+	}
+	it, err := h.svc.Get(id)
+	if err != nil {
+		if errors.Is(err, itemcatalog.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, it)
+}
+
+// GET /api/v1/catalog?q=사슴&limit=20
+func (h *CatalogHandler) Search(c *gin.Context) {
+	q := c.Query("q")
+	if q == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+	c.JSON(http.StatusOK, h.svc.Search(q, limit))
+}
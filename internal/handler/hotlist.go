@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HotListHandler struct {
+	svc    *service.HotListService
+	region string // region 쿼리가 없을 때 쓰는 지역 (cfg.Region)
+}
+
+func NewHotListHandler(s *service.HotListService, region string) *HotListHandler {
+	return &HotListHandler{svc: s, region: region}
+}
+
+// GET /api/v1/hot?region=kr
+func (h *HotListHandler) Feed(c *gin.Context) {
+	feed, err := h.svc.Feed(c.Request.Context(), c.DefaultQuery("region", h.region))
+	if err != nil {
+		if errors.Is(err, bdoapi.ErrUnknownRegion) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, feed)
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ItemHandler struct {
+	svc    *service.ItemService
+	region string // region 쿼리가 없을 때 쓰는 지역 (cfg.Region)
+}
+
+func NewItemHandler(s *service.ItemService, region string) *ItemHandler {
+	return &ItemHandler{svc: s, region: region}
+}
+
+// GET /api/v1/items/:id?region=kr
+// region이 없으면 모든 지역 row를 반환
+func (h *ItemHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item id"})
+		return
+	}
+
+	region := c.Query("region")
+	if region == "" {
+		items, err := h.svc.ListRegions(c.Request.Context(), id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, items)
+		return
+	}
+
+	it, err := h.svc.GetByID(c.Request.Context(), region, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, bdoapi.ErrUnknownRegion):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, repo.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, it)
+}
+
+// GET /api/v1/items/:id/enhancements?region=kr
+func (h *ItemHandler) ListEnhance(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item id"})
+		return
+	}
+	list, err := h.svc.ListEnhance(c.Request.Context(), c.DefaultQuery("region", h.region), id)
+	if err != nil {
+		if errors.Is(err, bdoapi.ErrUnknownRegion) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GET /api/v1/items/:id/enhancements/:level/ts?region=kr&hours=24
+// collector -enhance 모드로 쌓은 강화 단계별 시계열
+func (h *ItemHandler) ListEnhanceTS(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item id"})
+		return
+	}
+	level, err := strconv.Atoi(c.Param("level"))
+	if err != nil || level < 0 || level > bdoapi.MaxSubKey {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid enhancement level"})
+		return
+	}
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hours"})
+		return
+	}
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+	list, err := h.svc.ListEnhanceTS(c.Request.Context(), c.DefaultQuery("region", h.region), id, level, since)
+	if err != nil {
+		if errors.Is(err, bdoapi.ErrUnknownRegion) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GET /api/v1/regions
+func (h *ItemHandler) ListRegions(c *gin.Context) {
+	out := make([]gin.H, 0)
+	for _, r := range bdoapi.Regions() {
+		out = append(out, gin.H{
+			"code":      r.Code,
+			"name":      r.Name,
+			"time_zone": r.TimeZone,
+			"currency":  r.Currency,
+		})
+	}
+	c.JSON(http.StatusOK, out)
+}
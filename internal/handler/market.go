@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 업스트림이 느려도 핸들러가 오래 물려 있지 않도록
+const marketTimeout = 5 * time.Second
+
+type MarketHandler struct {
+	svc     *service.MarketService
+	catalog *service.CatalogService
+	region  string // region 쿼리가 없을 때 쓰는 지역 (cfg.Region)
+}
+
+func NewMarketHandler(s *service.MarketService, catalog *service.CatalogService, region string) *MarketHandler {
+	return &MarketHandler{svc: s, catalog: catalog, region: region}
+}
+
+// 이름 검색 시 거래소에 넘길 최대 아이템 수
+const searchNameLimit = 20
+
+// GET /api/v1/items/:id/orderbook?region=kr&grade=0
+func (h *MarketHandler) OrderBook(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item id"})
+		return
+	}
+	grade, err := strconv.Atoi(c.DefaultQuery("grade", "0"))
+	if err != nil || grade < 0 || grade > bdoapi.MaxSubKey {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid grade"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), marketTimeout)
+	defer cancel()
+	book, err := h.svc.OrderBook(ctx, c.DefaultQuery("region", h.region), id, grade)
+	if err != nil {
+		writeMarketError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, book)
+}
+
+// GET /api/v1/search?ids=15720,4901&region=kr
+// GET /api/v1/search?q=블랙스톤 (카탈로그에서 이름으로 아이템 id를 찾음)
+func (h *MarketHandler) Search(c *gin.Context) {
+	var ids []int
+	if q := strings.TrimSpace(c.Query("q")); q != "" && h.catalog != nil {
+		for _, m := range h.catalog.Search(q, searchNameLimit) {
+			ids = append(ids, m.Item.ID)
+		}
+		if len(ids) == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "no item matches " + q})
+			return
+		}
+	}
+	for _, s := range strings.Split(c.Query("ids"), ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item id: " + s})
+			return
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids or q is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), marketTimeout)
+	defer cancel()
+	list, err := h.svc.Search(ctx, c.DefaultQuery("region", h.region), ids)
+	if err != nil {
+		writeMarketError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GET /api/v1/categories
+func (h *MarketHandler) Categories(c *gin.Context) {
+	c.JSON(http.StatusOK, bdoapi.Categories().Mains)
+}
+
+// bdoapi 에러 → http 상태
+func writeMarketError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, bdoapi.ErrUnknownRegion):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, bdoapi.ErrMaintenance), errors.Is(err, bdoapi.ErrCircuitOpen):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case errors.Is(err, context.DeadlineExceeded):
+		c.JSON(http.StatusGatewayTimeout, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+	"bdo_calc_go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOrderBookGrade(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	srv := fakemarket.Start(fakemarket.Demo())
+	t.Cleanup(srv.Close)
+	svc := service.NewMarketService(logger.New(),
+		bdoapi.WithBaseURL(srv.URL+"/Trademarket/"),
+		bdoapi.WithRetryPolicy(bdoapi.NoRetry),
+		bdoapi.WithRateLimit(0, 1),
+	)
+	r := gin.New()
+	r.GET("/items/:id/orderbook", NewMarketHandler(svc, nil, bdoapi.DefaultRegion).OrderBook)
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", http.StatusOK},
+		{"?grade=0", http.StatusOK},
+		{"?grade=" + strconv.Itoa(bdoapi.MaxSubKey), http.StatusOK},
+		{"?grade=-1", http.StatusBadRequest},
+		{"?grade=" + strconv.Itoa(bdoapi.MaxSubKey+1), http.StatusBadRequest},
+		{"?grade=x", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/6201/orderbook"+tt.query, nil))
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+			var book bdoapi.OrderBook
+			if err := json.Unmarshal(w.Body.Bytes(), &book); err != nil {
+				t.Fatal(err)
+			}
+			if book.ItemID != 6201 {
+				t.Errorf("book = %+v", book)
+			}
+		})
+	}
+}
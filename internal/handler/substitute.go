@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SubstituteHandler struct {
+	svc    *service.SubstituteService
+	region string // region 쿼리가 없을 때 쓰는 지역 (cfg.Region)
+}
+
+func NewSubstituteHandler(s *service.SubstituteService, region string) *SubstituteHandler {
+	return &SubstituteHandler{svc: s, region: region}
+}
+
+// GET /api/v1/substitutes?region=kr
+func (h *SubstituteHandler) Latest(c *gin.Context) {
+	list, err := h.svc.Latest(c.Request.Context(), c.DefaultQuery("region", h.region))
+	if err != nil {
+		writeSubstituteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// GET /api/v1/substitutes/:group?region=kr&hours=24
+func (h *SubstituteHandler) History(c *gin.Context) {
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hours"})
+		return
+	}
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+	list, err := h.svc.History(c.Request.Context(), c.DefaultQuery("region", h.region), c.Param("group"), since)
+	if err != nil {
+		writeSubstituteError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func writeSubstituteError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, bdoapi.ErrUnknownRegion):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUnknownGroup):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"bdo_calc_go/internal/service"
+	"bdo_calc_go/pkg/bdoapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WaitListHandler struct {
+	svc    *service.WaitListService
+	region string // region 쿼리가 없을 때 쓰는 지역 (cfg.Region)
+}
+
+func NewWaitListHandler(s *service.WaitListService, region string) *WaitListHandler {
+	return &WaitListHandler{svc: s, region: region}
+}
+
+// GET /api/v1/waitlist?region=kr&hours=24
+func (h *WaitListHandler) List(c *gin.Context) {
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hours"})
+		return
+	}
+	list, err := h.svc.Recent(c.Request.Context(), c.DefaultQuery("region", h.region), time.Duration(hours)*time.Hour)
+	if err != nil {
+		if errors.Is(err, bdoapi.ErrUnknownRegion) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// hot_list 테이블, 폴링 1회에 보인 아이템 1개
+type HotObservation struct {
+	Region         string    `json:"region"`
+	ObservedAt     time.Time `json:"observed_at"`
+	ItemID         int       `json:"item_id"`
+	Enhancement    int       `json:"enhancement"`
+	BasePrice      int64     `json:"base_price"`
+	CurrentStock   int64     `json:"current_stock"`
+	PriceDirection int       `json:"price_direction"`
+	PriceChange    int64     `json:"price_change"`
+}
+
+// /api/v1/hot 응답 한 줄
+type HotItem struct {
+	HotObservation
+	Name         string    `json:"name,omitempty"`
+	Since        time.Time `json:"since"` // 이번에 연속으로 보이기 시작한 시각
+	DurationMin  int       `json:"duration_min"`
+	Observations int       `json:"observations"`
+}
@@ -1,7 +1,72 @@
 package model
 
+import (
+	"encoding/json"
+	"time"
+)
+
+// items 테이블, (region, item_id) 단위
+// 가격/수량은 고가 장비가 int 범위(약 21억)를 넘으므로 int64
 type Item struct {
-	ID             int
-	Name           string
-	LastTradePrice int
+	Region          string          `json:"region"`
+	ID              int             `json:"id"`
+	Name            string          `json:"name"`
+	Attrs           json.RawMessage `json:"attrs,omitempty"`
+	StockCount      int64           `json:"stock_count"`
+	BuyBidPrice     int64           `json:"buy_bid_price"`
+	SellBidPrice    int64           `json:"sell_bid_price"`
+	LastTradePrice  int64           `json:"last_trade_price"`
+	TotalTradeCount int64           `json:"total_trade_count"`
+	TotalBuyBid     int64           `json:"total_buy_bid"`
+	TotalSellBid    int64           `json:"total_sell_bid"`
+}
+
+// item_enhance 테이블, sub list 레코드(강화 단계) 단위
+type ItemEnhance struct {
+	Region          string    `json:"region"`
+	ItemID          int       `json:"item_id"`
+	MinEnhance      int       `json:"min_enhance"`
+	MaxEnhance      int       `json:"max_enhance"`
+	BasePrice       int64     `json:"base_price"`
+	StockCount      int64     `json:"stock_count"`
+	TotalTradeCount int64     `json:"total_trade_count"`
+	PriceHardCapMin int64     `json:"price_hardcap_min"`
+	PriceHardCapMax int64     `json:"price_hardcap_max"`
+	LastTradePrice  int64     `json:"last_trade_price"`
+	LastTradeTime   time.Time `json:"last_trade_time"`
+	PriceCapped     bool      `json:"price_capped"` // 기준가가 하드캡 최대에 걸림
+}
+
+// item_enhance_ts 테이블, 강화 단계별 수집 주기마다 1 row (collector -enhance)
+type ItemEnhanceTS struct {
+	Region         string    `json:"region"`
+	ItemID         int       `json:"item_id"`
+	Enhance        int       `json:"enhance"` // subKey, 구간 레코드면 시작 단계
+	MaxEnhance     int       `json:"max_enhance"`
+	Time           time.Time `json:"time"`
+	BasePrice      int64     `json:"base_price"`
+	StockCount     int64     `json:"stock_count"`
+	TradingVol     *int64    `json:"trading_vol"` // 이전 사이클 대비, 처음이면 null
+	LastTradePrice int64     `json:"last_trade_price"`
+	BuyBidPrice    int64     `json:"buy_bid_price"`  // 판매대기 최저가
+	SellBidPrice   int64     `json:"sell_bid_price"` // 구매대기 최고가
+	TotalBuyBid    int64     `json:"total_buy_bid"`
+	TotalSellBid   int64     `json:"total_sell_bid"`
+}
+
+// item_ts 테이블, 수집 주기마다 1 row
+type ItemTS struct {
+	Region       string    `json:"region"`
+	ItemID       int       `json:"item_id"`
+	Time         time.Time `json:"time"`
+	Name         string    `json:"name"`
+	TradingVol   int64     `json:"trading_vol"`
+	TradingPrice int64     `json:"trading_price"`
+}
+
+// 수집을 건너뛴 사이클 (점검/브레이커 open), 대시보드에서 구간을 비워 표시
+type CollectGap struct {
+	Region string    `json:"region"`
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
 }
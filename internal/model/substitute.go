@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// substitute_choice 테이블, 사이클마다 그룹별 1 row
+type SubstituteChoice struct {
+	Region   string    `json:"region"`
+	Group    string    `json:"group"`
+	ChosenAt time.Time `json:"chosen_at"`
+	ItemID   int       `json:"item_id"`
+	Name     string    `json:"name"`
+	Price    int64     `json:"price"`
+	Stock    int64     `json:"stock"`
+	Source   string    `json:"source"`   // 가격 기준 (sell_bid, buy_bid, last_trade)
+	Fallback bool      `json:"fallback"` // 조건에 맞는 아이템이 없어 기본 아이템을 고름
+}
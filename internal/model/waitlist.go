@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// wait_list 테이블, 같은 (아이템, 강화, 가격, 등록시각) 매물은 quantity로 묶음
+type WaitListEntry struct {
+	Region      string    `json:"region"`
+	ItemID      int       `json:"item_id"`
+	Enhancement int       `json:"enhancement"`
+	Price       int64     `json:"price"`
+	LiveAt      time.Time `json:"live_at"`
+	Quantity    int       `json:"quantity"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
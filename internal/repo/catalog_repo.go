@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"bdo_calc_go/pkg/itemcatalog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// 아이템 카탈로그는 items.item_attrs(jsonb)에 저장
+// 수집하지 않는 아이템도 row가 생기므로 시세 컬럼(stock_count 등)은 NULL로 둠, 수집 대상 판단은 stock_count로
+type CatalogRepo interface {
+	// 지역별 items row에 반영 (없으면 생성), names는 지역 코드 → 표시 이름
+	Save(ctx context.Context, it *itemcatalog.Item, names map[string]string) error
+	LoadAll(ctx context.Context) ([]*itemcatalog.Item, error)
+}
+
+type catalogRepoPG struct {
+	pool *pgxpool.Pool
+}
+
+func NewCatalogRepoPG(pool *pgxpool.Pool) CatalogRepo {
+	return &catalogRepoPG{pool: pool}
+}
+
+func (r *catalogRepoPG) Save(ctx context.Context, it *itemcatalog.Item, names map[string]string) error {
+	attrs, err := json.Marshal(it)
+	if err != nil {
+		return err
+	}
+	batch := &pgx.Batch{}
+	for region, name := range names {
+		batch.Queue(`
+INSERT INTO items (region, item_id, name, item_attrs)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (region, item_id) DO UPDATE SET
+  name       = EXCLUDED.name,
+  item_attrs = EXCLUDED.item_attrs`,
+			region, it.ID, name, attrs)
+	}
+	return r.pool.SendBatch(ctx, batch).Close()
+}
+
+// 지역마다 같은 attrs가 있으므로 item_id당 하나만
+func (r *catalogRepoPG) LoadAll(ctx context.Context) ([]*itemcatalog.Item, error) {
+	rows, err := r.pool.Query(ctx, `
+SELECT DISTINCT ON (item_id) item_attrs
+FROM items
+WHERE item_attrs IS NOT NULL
+ORDER BY item_id, region`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]*itemcatalog.Item, 0)
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+		var it itemcatalog.Item
+		if err := json.Unmarshal(raw, &it); err != nil {
+			return nil, fmt.Errorf("item_attrs: %w", err)
+		}
+		out = append(out, &it)
+	}
+	return out, rows.Err()
+}
@@ -22,13 +22,3 @@ func Open(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
 	}
 	return pool, nil
 }
-
-func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, `
-CREATE TABLE IF NOT EXISTS items (
-  id TEXT PRIMARY KEY,
-  name TEXT NOT NULL,
-  updated_at TIMESTAMPTZ NOT NULL
-)`)
-	return err
-}
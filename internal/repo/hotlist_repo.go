@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"bdo_calc_go/internal/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// 인터페이스
+type HotListRepo interface {
+	// 폴링 1회 기록, 빈 응답이어도 폴링 시각은 남김
+	InsertSnapshot(ctx context.Context, region string, polledAt time.Time, obs []*model.HotObservation) error
+	// since 이후 관측 전부, 시간 순
+	ListSince(ctx context.Context, region string, since time.Time) ([]*model.HotObservation, error)
+	// 가장 최근 폴링 시각, 없으면 zero
+	LatestPoll(ctx context.Context, region string) (time.Time, error)
+	// before 이전 관측/폴링 기록 삭제, 지운 관측 수 반환
+	DeleteBefore(ctx context.Context, region string, before time.Time) (int64, error)
+}
+
+type hotListRepoPG struct {
+	pool *pgxpool.Pool
+}
+
+func NewHotListRepoPG(pool *pgxpool.Pool) HotListRepo {
+	return &hotListRepoPG{pool: pool}
+}
+
+func (r *hotListRepoPG) InsertSnapshot(ctx context.Context, region string, polledAt time.Time, obs []*model.HotObservation) error {
+	batch := &pgx.Batch{}
+	batch.Queue(`
+INSERT INTO hot_list_poll (region, polled_at, entries) VALUES ($1, $2, $3)
+ON CONFLICT (region, polled_at) DO UPDATE SET entries = EXCLUDED.entries`, region, polledAt, len(obs))
+	for _, o := range obs {
+		batch.Queue(`
+INSERT INTO hot_list (region, observed_at, item_id, enhancement, base_price, current_stock, price_direction, price_change)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (region, item_id, enhancement, observed_at) DO NOTHING`,
+			o.Region, o.ObservedAt, o.ItemID, o.Enhancement, o.BasePrice, o.CurrentStock, o.PriceDirection, o.PriceChange)
+	}
+	return r.pool.SendBatch(ctx, batch).Close()
+}
+
+func (r *hotListRepoPG) ListSince(ctx context.Context, region string, since time.Time) ([]*model.HotObservation, error) {
+	rows, err := r.pool.Query(ctx, `
+SELECT region, observed_at, item_id, enhancement, base_price, current_stock, price_direction, price_change
+FROM hot_list
+WHERE region = $1 AND observed_at >= $2
+ORDER BY observed_at`, region, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]*model.HotObservation, 0)
+	for rows.Next() {
+		var o model.HotObservation
+		if err := rows.Scan(&o.Region, &o.ObservedAt, &o.ItemID, &o.Enhancement, &o.BasePrice,
+			&o.CurrentStock, &o.PriceDirection, &o.PriceChange); err != nil {
+			return nil, err
+		}
+		out = append(out, &o)
+	}
+	return out, rows.Err()
+}
+
+func (r *hotListRepoPG) LatestPoll(ctx context.Context, region string) (time.Time, error) {
+	var t *time.Time
+	err := r.pool.QueryRow(ctx, `SELECT max(polled_at) FROM hot_list_poll WHERE region = $1`, region).Scan(&t)
+	if err != nil || t == nil {
+		return time.Time{}, err
+	}
+	return *t, nil
+}
+
+func (r *hotListRepoPG) DeleteBefore(ctx context.Context, region string, before time.Time) (int64, error) {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM hot_list_poll WHERE region = $1 AND polled_at < $2`, region, before); err != nil {
+		return 0, err
+	}
+	tag, err := r.pool.Exec(ctx, `DELETE FROM hot_list WHERE region = $1 AND observed_at < $2`, region, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
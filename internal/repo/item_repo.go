@@ -1 +1,281 @@
 package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bdo_calc_go/internal/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// 인터페이스
+type ItemRepo interface {
+	Upsert(ctx context.Context, it *model.Item) error
+	FindByID(ctx context.Context, region string, id int) (*model.Item, error)
+	// 같은 아이템의 모든 지역 row
+	ListRegions(ctx context.Context, id int) ([]*model.Item, error)
+	InsertTS(ctx context.Context, ts *model.ItemTS) error
+	InsertGap(ctx context.Context, gap *model.CollectGap) error
+	UpsertEnhance(ctx context.Context, e *model.ItemEnhance) error
+	ListEnhance(ctx context.Context, region string, id int) ([]*model.ItemEnhance, error)
+	// 한 아이템의 강화 단계별 row를 한 번에
+	InsertEnhanceTS(ctx context.Context, rows []*model.ItemEnhanceTS) error
+	ListEnhanceTS(ctx context.Context, region string, id, enhance int, since time.Time) ([]*model.ItemEnhanceTS, error)
+	// 수집기가 시세를 채운 아이템 중 아직 백필하지 않은 것 (items.backfilled_at NULL)
+	// 카탈로그로만 들어온 row(stock_count NULL)는 제외
+	// (item_ts 유무로 보면 수집기가 첫 사이클에 바로 item_ts를 쓰므로 몇 분 뒤엔 대상이 없음)
+	ListNotBackfilled(ctx context.Context, region string) ([]*model.Item, error)
+	// 가격 기록으로 item_ts 채우고 backfilled_at 기록, 거래량은 알 수 없어 NULL
+	// rows가 비어도 기록 (가격 기록이 없는 아이템을 매번 다시 조회하지 않게)
+	InsertBackfillTS(ctx context.Context, region string, id int, rows []*model.ItemTS) error
+}
+
+type itemRepoPG struct {
+	pool *pgxpool.Pool
+}
+
+func NewItemRepoPG(pool *pgxpool.Pool) ItemRepo {
+	return &itemRepoPG{pool: pool}
+}
+
+const itemColumns = `region, item_id, name, item_attrs, stock_count, buy_bid_price, sell_bid_price,
+  last_trade_price, total_trade_count, total_buy_bid, total_sell_bid`
+
+func (r *itemRepoPG) Upsert(ctx context.Context, it *model.Item) error {
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO items (`+itemColumns+`)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (region, item_id) DO UPDATE SET
+  name              = COALESCE(NULLIF(EXCLUDED.name, ''), items.name),
+  item_attrs        = COALESCE(EXCLUDED.item_attrs, items.item_attrs),
+  stock_count       = EXCLUDED.stock_count,
+  buy_bid_price     = EXCLUDED.buy_bid_price,
+  sell_bid_price    = EXCLUDED.sell_bid_price,
+  last_trade_price  = EXCLUDED.last_trade_price,
+  total_trade_count = EXCLUDED.total_trade_count,
+  total_buy_bid     = EXCLUDED.total_buy_bid,
+  total_sell_bid    = EXCLUDED.total_sell_bid`,
+		it.Region, it.ID, it.Name, nullJSON(it.Attrs), it.StockCount, it.BuyBidPrice, it.SellBidPrice,
+		it.LastTradePrice, it.TotalTradeCount, it.TotalBuyBid, it.TotalSellBid)
+	return err
+}
+
+func (r *itemRepoPG) FindByID(ctx context.Context, region string, id int) (*model.Item, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+itemColumns+` FROM items WHERE region = $1 AND item_id = $2`, region, id)
+	it, err := scanItem(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return it, err
+}
+
+func (r *itemRepoPG) ListRegions(ctx context.Context, id int) ([]*model.Item, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+itemColumns+` FROM items WHERE item_id = $1 ORDER BY region`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]*model.Item, 0)
+	for rows.Next() {
+		it, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, it)
+	}
+	return out, rows.Err()
+}
+
+func (r *itemRepoPG) InsertTS(ctx context.Context, ts *model.ItemTS) error {
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO item_ts (region, item_id, time, name, trading_vol, trading_price)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (region, item_id, time) DO NOTHING`,
+		ts.Region, ts.ItemID, ts.Time, ts.Name, ts.TradingVol, ts.TradingPrice)
+	return err
+}
+
+func (r *itemRepoPG) InsertGap(ctx context.Context, gap *model.CollectGap) error {
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO collect_gaps (region, time, reason)
+VALUES ($1, $2, $3)
+ON CONFLICT (region, time) DO NOTHING`,
+		gap.Region, gap.Time, gap.Reason)
+	return err
+}
+
+func (r *itemRepoPG) UpsertEnhance(ctx context.Context, e *model.ItemEnhance) error {
+	var lastTime *time.Time
+	if !e.LastTradeTime.IsZero() {
+		lastTime = &e.LastTradeTime
+	}
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO item_enhance (region, item_id, min_enhance, max_enhance, base_price, stock_count,
+  total_trade_count, price_hardcap_min, price_hardcap_max, last_trade_price, last_trade_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (region, item_id, min_enhance) DO UPDATE SET
+  max_enhance       = EXCLUDED.max_enhance,
+  base_price        = EXCLUDED.base_price,
+  stock_count       = EXCLUDED.stock_count,
+  total_trade_count = EXCLUDED.total_trade_count,
+  price_hardcap_min = EXCLUDED.price_hardcap_min,
+  price_hardcap_max = EXCLUDED.price_hardcap_max,
+  last_trade_price  = EXCLUDED.last_trade_price,
+  last_trade_time   = EXCLUDED.last_trade_time`,
+		e.Region, e.ItemID, e.MinEnhance, e.MaxEnhance, e.BasePrice, e.StockCount,
+		e.TotalTradeCount, e.PriceHardCapMin, e.PriceHardCapMax, e.LastTradePrice, lastTime)
+	return err
+}
+
+func (r *itemRepoPG) ListEnhance(ctx context.Context, region string, id int) ([]*model.ItemEnhance, error) {
+	rows, err := r.pool.Query(ctx, `
+SELECT region, item_id, min_enhance, max_enhance, base_price, stock_count, total_trade_count,
+  price_hardcap_min, price_hardcap_max, last_trade_price, last_trade_time
+FROM item_enhance WHERE region = $1 AND item_id = $2 ORDER BY min_enhance`, region, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]*model.ItemEnhance, 0)
+	for rows.Next() {
+		var (
+			e        model.ItemEnhance
+			lastTime *time.Time
+		)
+		if err := rows.Scan(&e.Region, &e.ItemID, &e.MinEnhance, &e.MaxEnhance, &e.BasePrice, &e.StockCount,
+			&e.TotalTradeCount, &e.PriceHardCapMin, &e.PriceHardCapMax, &e.LastTradePrice, &lastTime); err != nil {
+			return nil, err
+		}
+		if lastTime != nil {
+			e.LastTradeTime = *lastTime
+		}
+		e.PriceCapped = e.PriceHardCapMax > 0 && e.BasePrice >= e.PriceHardCapMax
+		out = append(out, &e)
+	}
+	return out, rows.Err()
+}
+
+func (r *itemRepoPG) InsertEnhanceTS(ctx context.Context, rows []*model.ItemEnhanceTS) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	batch := &pgx.Batch{}
+	for _, ts := range rows {
+		batch.Queue(`
+INSERT INTO item_enhance_ts (region, item_id, enhance, time, max_enhance, base_price, stock_count, trading_vol,
+  last_trade_price, buy_bid_price, sell_bid_price, total_buy_bid, total_sell_bid)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+ON CONFLICT (region, item_id, enhance, time) DO NOTHING`,
+			ts.Region, ts.ItemID, ts.Enhance, ts.Time, ts.MaxEnhance, ts.BasePrice, ts.StockCount, ts.TradingVol,
+			ts.LastTradePrice, ts.BuyBidPrice, ts.SellBidPrice, ts.TotalBuyBid, ts.TotalSellBid)
+	}
+	return r.pool.SendBatch(ctx, batch).Close()
+}
+
+func (r *itemRepoPG) ListEnhanceTS(ctx context.Context, region string, id, enhance int, since time.Time) ([]*model.ItemEnhanceTS, error) {
+	rows, err := r.pool.Query(ctx, `
+SELECT region, item_id, enhance, time, max_enhance, base_price, stock_count, trading_vol,
+  last_trade_price, buy_bid_price, sell_bid_price, total_buy_bid, total_sell_bid
+FROM item_enhance_ts
+WHERE region = $1 AND item_id = $2 AND enhance = $3 AND time >= $4
+ORDER BY time`, region, id, enhance, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]*model.ItemEnhanceTS, 0)
+	for rows.Next() {
+		var ts model.ItemEnhanceTS
+		if err := rows.Scan(&ts.Region, &ts.ItemID, &ts.Enhance, &ts.Time, &ts.MaxEnhance, &ts.BasePrice, &ts.StockCount, &ts.TradingVol,
+			&ts.LastTradePrice, &ts.BuyBidPrice, &ts.SellBidPrice, &ts.TotalBuyBid, &ts.TotalSellBid); err != nil {
+			return nil, err
+		}
+		out = append(out, &ts)
+	}
+	return out, rows.Err()
+}
+
+func (r *itemRepoPG) ListNotBackfilled(ctx context.Context, region string) ([]*model.Item, error) {
+	rows, err := r.pool.Query(ctx, `
+SELECT `+itemColumns+` FROM items
+WHERE region = $1 AND stock_count IS NOT NULL AND backfilled_at IS NULL
+ORDER BY item_id`, region)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]*model.Item, 0)
+	for rows.Next() {
+		it, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, it)
+	}
+	return out, rows.Err()
+}
+
+func (r *itemRepoPG) InsertBackfillTS(ctx context.Context, region string, id int, rows []*model.ItemTS) error {
+	batch := &pgx.Batch{}
+	// 대상 월 파티션이 없으면 insert가 실패하므로 먼저 보장
+	months := map[time.Time]bool{}
+	for _, ts := range rows {
+		m := time.Date(ts.Time.Year(), ts.Time.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if !months[m] {
+			months[m] = true
+			batch.Queue(`SELECT public.ensure_month_partition('public.item_ts', $1::date)`, m)
+		}
+	}
+	for _, ts := range rows {
+		batch.Queue(`
+INSERT INTO item_ts (region, item_id, time, name, trading_vol, trading_price)
+VALUES ($1, $2, $3, $4, NULL, $5)
+ON CONFLICT (region, item_id, time) DO NOTHING`,
+			ts.Region, ts.ItemID, ts.Time, ts.Name, ts.TradingPrice)
+	}
+	batch.Queue(`UPDATE items SET backfilled_at = now() WHERE region = $1 AND item_id = $2`, region, id)
+	return r.pool.SendBatch(ctx, batch).Close()
+}
+
+func scanItem(row pgx.Row) (*model.Item, error) {
+	var (
+		it    model.Item
+		attrs []byte
+		// 수집 전 컬럼은 NULL일 수 있음
+		stock, buy, sell, last, total, totalBuy, totalSell *int64
+	)
+	if err := row.Scan(&it.Region, &it.ID, &it.Name, &attrs, &stock, &buy, &sell,
+		&last, &total, &totalBuy, &totalSell); err != nil {
+		return nil, err
+	}
+	it.Attrs = attrs
+	it.StockCount = derefInt(stock)
+	it.BuyBidPrice = derefInt(buy)
+	it.SellBidPrice = derefInt(sell)
+	it.LastTradePrice = derefInt(last)
+	it.TotalTradeCount = derefInt(total)
+	it.TotalBuyBid = derefInt(totalBuy)
+	it.TotalSellBid = derefInt(totalSell)
+	return &it, nil
+}
+
+func derefInt(p *int64) int64 {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+func nullJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// 여러 collector 레플리카가 같이 쓰는 초당 요청 예산 (bdoapi.Limiter 구현)
+// pg_advisory_xact_lock으로 같은 key의 예산 갱신을 직렬화함
+type PGBudgetLimiter struct {
+	pool      *pgxpool.Pool
+	key       string // ex) "bdoapi:kr"
+	perSecond int
+}
+
+func NewPGBudgetLimiter(pool *pgxpool.Pool, key string, perSecond int) *PGBudgetLimiter {
+	return &PGBudgetLimiter{pool: pool, key: key, perSecond: perSecond}
+}
+
+func (l *PGBudgetLimiter) Wait(ctx context.Context) error {
+	return waitBudget(ctx, l.tryAcquire)
+}
+
+// 토큰을 얻을 때까지 acquire가 알려준 시간만큼 기다렸다 다시 시도
+func waitBudget(ctx context.Context, acquire func(context.Context) (time.Duration, error)) error {
+	for {
+		wait, err := acquire(ctx)
+		if err != nil {
+			return err
+		}
+		if wait <= 0 {
+			return nil
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// 토큰을 얻으면 0, 아니면 다음 윈도우까지 남은 시간
+// 시간은 레플리카 간 시계 차이를 피하려고 DB 시계 기준
+func (l *PGBudgetLimiter) tryAcquire(ctx context.Context) (time.Duration, error) {
+	tx, err := l.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, l.key); err != nil {
+		return 0, err
+	}
+
+	var (
+		used   int
+		remain float64
+	)
+	err = tx.QueryRow(ctx, `
+INSERT INTO api_rate_budget (key, window_start, used)
+VALUES ($1, date_trunc('second', clock_timestamp()), 1)
+ON CONFLICT (key) DO UPDATE SET
+  used = CASE WHEN api_rate_budget.window_start < date_trunc('second', clock_timestamp())
+              THEN 1 ELSE api_rate_budget.used + 1 END,
+  window_start = date_trunc('second', clock_timestamp())
+RETURNING used, extract(epoch FROM window_start + interval '1 second' - clock_timestamp())::float8`,
+		l.key).Scan(&used, &remain)
+	if err != nil {
+		return 0, err
+	}
+	if used > l.perSecond {
+		// 예산 초과: 롤백해서 카운트를 올리지 않음
+		return time.Duration(remain * float64(time.Second)), nil
+	}
+	return 0, tx.Commit(ctx)
+}
@@ -0,0 +1,124 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// 정해진 대기 시간을 차례로 돌려주는 acquire
+func fakeAcquire(calls *int, waits ...time.Duration) func(context.Context) (time.Duration, error) {
+	return func(context.Context) (time.Duration, error) {
+		*calls++
+		if *calls > len(waits) {
+			return 0, nil
+		}
+		return waits[*calls-1], nil
+	}
+}
+
+func TestWaitBudget(t *testing.T) {
+	errDB := errors.New("db down")
+	tests := []struct {
+		name      string
+		acquire   func(calls *int) func(context.Context) (time.Duration, error)
+		timeout   time.Duration
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "token right away",
+			acquire:   func(calls *int) func(context.Context) (time.Duration, error) { return fakeAcquire(calls) },
+			wantCalls: 1,
+		},
+		{
+			name: "retries after each window",
+			acquire: func(calls *int) func(context.Context) (time.Duration, error) {
+				return fakeAcquire(calls, 10*time.Millisecond, 10*time.Millisecond)
+			},
+			wantCalls: 3,
+		},
+		{
+			name: "db error",
+			acquire: func(calls *int) func(context.Context) (time.Duration, error) {
+				return func(context.Context) (time.Duration, error) { *calls++; return 0, errDB }
+			},
+			wantCalls: 1,
+			wantErr:   errDB,
+		},
+		{
+			name: "cancel while waiting",
+			acquire: func(calls *int) func(context.Context) (time.Duration, error) {
+				return fakeAcquire(calls, time.Hour)
+			},
+			timeout:   20 * time.Millisecond,
+			wantCalls: 1,
+			wantErr:   context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			var calls int
+			err := waitBudget(ctx, tt.acquire(&calls))
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("%d acquire calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// TEST_DATABASE_URL이 있을 때만 (api_rate_budget 테이블만 만듦)
+func TestPGBudgetLimiter(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	pool, err := Open(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+	if _, err := pool.Exec(ctx, `
+CREATE TABLE IF NOT EXISTS api_rate_budget (
+  key          text        PRIMARY KEY,
+  window_start timestamptz NOT NULL,
+  used         int         NOT NULL
+)`); err != nil {
+		t.Fatal(err)
+	}
+	key := fmt.Sprintf("test:%d", time.Now().UnixNano())
+	defer pool.Exec(context.Background(), `DELETE FROM api_rate_budget WHERE key = $1`, key)
+
+	// 초당 3개면 7개는 최소 세 윈도우(1초 넘게)에 걸침
+	l := NewPGBudgetLimiter(pool, key, 3)
+	begin := time.Now()
+	for range 7 {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := time.Since(begin); got < time.Second || got > 4*time.Second {
+		t.Errorf("7 waits at 3/s took %s", got)
+	}
+	var used int
+	if err := pool.QueryRow(ctx, `SELECT used FROM api_rate_budget WHERE key = $1`, key).Scan(&used); err != nil {
+		t.Fatal(err)
+	}
+	if used < 1 || used > 3 {
+		t.Errorf("used = %d in the last window, want 1..3", used)
+	}
+}
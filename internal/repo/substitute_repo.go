@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"bdo_calc_go/internal/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// 인터페이스
+type SubstituteRepo interface {
+	Insert(ctx context.Context, choices []*model.SubstituteChoice) error
+	// 그룹별 가장 최근 선택
+	Latest(ctx context.Context, region string) ([]*model.SubstituteChoice, error)
+	// since 이후 선택 기록, 시간 순
+	History(ctx context.Context, region, group string, since time.Time) ([]*model.SubstituteChoice, error)
+}
+
+type substituteRepoPG struct {
+	pool *pgxpool.Pool
+}
+
+func NewSubstituteRepoPG(pool *pgxpool.Pool) SubstituteRepo {
+	return &substituteRepoPG{pool: pool}
+}
+
+const substituteColumns = `s.region, s.group_name, s.chosen_at, s.item_id, COALESCE(i.name, ''),
+  COALESCE(s.price, 0), COALESCE(s.stock, 0), s.source, s.fallback`
+
+func (r *substituteRepoPG) Insert(ctx context.Context, choices []*model.SubstituteChoice) error {
+	if len(choices) == 0 {
+		return nil
+	}
+	batch := &pgx.Batch{}
+	for _, c := range choices {
+		batch.Queue(`
+INSERT INTO substitute_choice (region, group_name, chosen_at, item_id, price, stock, source, fallback)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (region, group_name, chosen_at) DO UPDATE SET
+  item_id  = EXCLUDED.item_id,
+  price    = EXCLUDED.price,
+  stock    = EXCLUDED.stock,
+  source   = EXCLUDED.source,
+  fallback = EXCLUDED.fallback`,
+			c.Region, c.Group, c.ChosenAt, c.ItemID, c.Price, c.Stock, c.Source, c.Fallback)
+	}
+	return r.pool.SendBatch(ctx, batch).Close()
+}
+
+func (r *substituteRepoPG) Latest(ctx context.Context, region string) ([]*model.SubstituteChoice, error) {
+	rows, err := r.pool.Query(ctx, `
+SELECT DISTINCT ON (s.group_name) `+substituteColumns+`
+FROM substitute_choice s
+LEFT JOIN items i ON i.region = s.region AND i.item_id = s.item_id
+WHERE s.region = $1
+ORDER BY s.group_name, s.chosen_at DESC`, region)
+	if err != nil {
+		return nil, err
+	}
+	return scanSubstitutes(rows)
+}
+
+func (r *substituteRepoPG) History(ctx context.Context, region, group string, since time.Time) ([]*model.SubstituteChoice, error) {
+	rows, err := r.pool.Query(ctx, `
+SELECT `+substituteColumns+`
+FROM substitute_choice s
+LEFT JOIN items i ON i.region = s.region AND i.item_id = s.item_id
+WHERE s.region = $1 AND s.group_name = $2 AND s.chosen_at >= $3
+ORDER BY s.chosen_at`, region, group, since)
+	if err != nil {
+		return nil, err
+	}
+	return scanSubstitutes(rows)
+}
+
+func scanSubstitutes(rows pgx.Rows) ([]*model.SubstituteChoice, error) {
+	defer rows.Close()
+	out := make([]*model.SubstituteChoice, 0)
+	for rows.Next() {
+		var c model.SubstituteChoice
+		if err := rows.Scan(&c.Region, &c.Group, &c.ChosenAt, &c.ItemID, &c.Name,
+			&c.Price, &c.Stock, &c.Source, &c.Fallback); err != nil {
+			return nil, err
+		}
+		out = append(out, &c)
+	}
+	return out, rows.Err()
+}
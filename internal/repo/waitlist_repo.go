@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"bdo_calc_go/internal/model"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// 인터페이스
+type WaitListRepo interface {
+	// first_seen은 처음 넣을 때만 기록됨
+	Upsert(ctx context.Context, e *model.WaitListEntry) error
+	// since 이후에 보인 매물, 등록시각 순
+	ListSeenSince(ctx context.Context, region string, since time.Time) ([]*model.WaitListEntry, error)
+}
+
+type waitListRepoPG struct {
+	pool *pgxpool.Pool
+}
+
+func NewWaitListRepoPG(pool *pgxpool.Pool) WaitListRepo {
+	return &waitListRepoPG{pool: pool}
+}
+
+func (r *waitListRepoPG) Upsert(ctx context.Context, e *model.WaitListEntry) error {
+	_, err := r.pool.Exec(ctx, `
+INSERT INTO wait_list (region, item_id, enhancement, price, live_at, quantity, first_seen, last_seen)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+ON CONFLICT (region, item_id, enhancement, price, live_at) DO UPDATE SET
+  quantity  = EXCLUDED.quantity,
+  last_seen = EXCLUDED.last_seen`,
+		e.Region, e.ItemID, e.Enhancement, e.Price, e.LiveAt, e.Quantity, e.LastSeen)
+	return err
+}
+
+func (r *waitListRepoPG) ListSeenSince(ctx context.Context, region string, since time.Time) ([]*model.WaitListEntry, error) {
+	rows, err := r.pool.Query(ctx, `
+SELECT region, item_id, enhancement, price, live_at, quantity, first_seen, last_seen
+FROM wait_list
+WHERE region = $1 AND last_seen >= $2
+ORDER BY live_at, item_id`, region, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]*model.WaitListEntry, 0)
+	for rows.Next() {
+		var e model.WaitListEntry
+		if err := rows.Scan(&e.Region, &e.ItemID, &e.Enhancement, &e.Price, &e.LiveAt,
+			&e.Quantity, &e.FirstSeen, &e.LastSeen); err != nil {
+			return nil, err
+		}
+		out = append(out, &e)
+	}
+	return out, rows.Err()
+}
@@ -1,13 +1,26 @@
 package router
 
 import (
+	"expvar"
+
 	"bdo_calc_go/internal/handler"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Dependencies struct {
-	UserHandler *handler.UserHandler
+	UserHandler       *handler.UserHandler
+	ItemHandler       *handler.ItemHandler
+	MarketHandler     *handler.MarketHandler
+	WaitListHandler   *handler.WaitListHandler
+	HotListHandler    *handler.HotListHandler
+	CatalogHandler    *handler.CatalogHandler
+	SubstituteHandler *handler.SubstituteHandler
+}
+
+// 내부 전용 라우트 (카운터 등), 공개 포트가 아닌 별도 리스너에 붙임
+func RegisterAdmin(r *gin.Engine) {
+	r.GET("/debug/vars", gin.WrapH(expvar.Handler()))
 }
 
 func Register(r *gin.Engine, d Dependencies) {
@@ -25,5 +38,27 @@ func Register(r *gin.Engine, d Dependencies) {
 			users.GET("/:id", d.UserHandler.GetByID)
 			users.GET("", d.UserHandler.List)
 		}
+
+		items := v1.Group("/items")
+		{
+			items.GET("/:id", d.ItemHandler.GetByID)
+			items.GET("/:id/enhancements", d.ItemHandler.ListEnhance)
+			items.GET("/:id/enhancements/:level/ts", d.ItemHandler.ListEnhanceTS)
+			items.GET("/:id/orderbook", d.MarketHandler.OrderBook)
+		}
+		v1.GET("/regions", d.ItemHandler.ListRegions)
+		v1.GET("/waitlist", d.WaitListHandler.List)
+		v1.GET("/search", d.MarketHandler.Search)
+		v1.GET("/hot", d.HotListHandler.Feed)
+		v1.GET("/categories", d.MarketHandler.Categories)
+
+		catalog := v1.Group("/catalog")
+		{
+			catalog.GET("", d.CatalogHandler.Search)
+			catalog.GET("/:id", d.CatalogHandler.GetByID)
+		}
+
+		v1.GET("/substitutes", d.SubstituteHandler.Latest)
+		v1.GET("/substitutes/:group", d.SubstituteHandler.History)
 	}
 }
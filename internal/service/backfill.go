@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"bdo_calc_go/internal/model"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/logger"
+)
+
+// item_ts 보관 기간 (schema.sql의 파티션 드롭과 맞춤)
+const tsRetention = 30 * 24 * time.Hour
+
+// 새로 추적하는 아이템의 item_ts를 GetMarketPriceInfo 일별 가격으로 채움
+// 첫 한 달 동안 대시보드가 비어 보이지 않게 하는 용도
+type PriceBackfiller struct {
+	client *bdoapi.Client
+	repo   repo.ItemRepo
+	logger logger.Logger
+}
+
+func NewPriceBackfiller(c *bdoapi.Client, r repo.ItemRepo, l logger.Logger) *PriceBackfiller {
+	return &PriceBackfiller{client: c, repo: r, logger: l}
+}
+
+// 수집 중인데 아직 백필하지 않은 아이템 전부, 채운 아이템 수 반환
+func (s *PriceBackfiller) BackfillPending(ctx context.Context) (int, error) {
+	items, err := s.repo.ListNotBackfilled(ctx, s.client.Region().Code)
+	if err != nil {
+		return 0, err
+	}
+	done := 0
+	for _, it := range items {
+		if err := s.Backfill(ctx, it.ID, it.Name); err != nil {
+			if abortCycle(err) {
+				return done, err
+			}
+			s.logger.Errorf("[%s] backfill %d skipped: %v", s.client.Region().Code, it.ID, err)
+			continue
+		}
+		done++
+	}
+	return done, nil
+}
+
+// 강화 0단계 가격 기록으로 보관 기간 안쪽만 채움
+func (s *PriceBackfiller) Backfill(ctx context.Context, id int, name string) error {
+	hist, err := s.client.GetMarketPriceInfo(ctx, id, 0)
+	if err != nil {
+		return err
+	}
+
+	region := s.client.Region().Code
+	cutoff := time.Now().Add(-tsRetention)
+	rows := make([]*model.ItemTS, 0, len(hist.Points))
+	for _, p := range hist.Points {
+		if p.Date.Before(cutoff) || p.Price <= 0 {
+			continue
+		}
+		rows = append(rows, &model.ItemTS{
+			Region:       region,
+			ItemID:       id,
+			Time:         p.Date,
+			Name:         name,
+			TradingPrice: p.Price,
+		})
+	}
+	if err := s.repo.InsertBackfillTS(ctx, region, id, rows); err != nil {
+		return err
+	}
+	s.logger.Infof("[%s] backfilled %d: %d points", region, id, len(rows))
+	return nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+)
+
+// 수집기가 item_ts를 쓰기 시작한 뒤에도 새 아이템은 한 번만 백필
+func TestBackfillPending(t *testing.T) {
+	m := fakemarket.Demo()
+	c, r := newTestCollector(t, m)
+	ctx := context.Background()
+	for i := range 2 {
+		if err := c.RunCycle(ctx, collectAt.Add(time.Duration(i)*10*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(r.ts) == 0 {
+		t.Fatal("collector wrote no item_ts rows")
+	}
+
+	b := NewPriceBackfiller(c.client, r, nopLogger{})
+	n, err := b.BackfillPending(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n == 0 || n != len(r.items) {
+		t.Errorf("backfilled %d items, want %d", n, len(r.items))
+	}
+	if len(r.backfill) == 0 {
+		t.Error("no backfill rows")
+	}
+	before := m.Requests("GetMarketPriceInfo")
+
+	// 두 번째 실행은 대상 없음
+	if n, err := b.BackfillPending(ctx); err != nil || n != 0 {
+		t.Errorf("second run backfilled %d (%v), want 0", n, err)
+	}
+	if got := m.Requests("GetMarketPriceInfo"); got != before {
+		t.Errorf("price info requests %d → %d on second run", before, got)
+	}
+
+	// 새로 수집된 아이템만
+	m.AddItem(fakemarket.Item{ID: 99001, Name: "new", MainCategory: c.categories[0].MainID, SubCategory: c.categories[0].SubID,
+		Levels: []*fakemarket.Level{{BasePrice: 1000, Stock: 100, LastTradePrice: 1000, History: []int64{990, 1000}}}})
+	if err := c.RunCycle(ctx, collectAt.Add(20*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := b.BackfillPending(ctx); err != nil || n != 1 {
+		t.Errorf("backfilled %d (%v) after a new item, want 1", n, err)
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/itemcatalog"
+	"bdo_calc_go/pkg/logger"
+)
+
+// 아이템 카탈로그 (DB의 item_attrs를 메모리에 올려 조회/검색)
+type CatalogService struct {
+	repo    repo.CatalogRepo
+	catalog *itemcatalog.Catalog
+	logger  logger.Logger
+}
+
+func NewCatalogService(r repo.CatalogRepo, l logger.Logger) *CatalogService {
+	return &CatalogService{repo: r, catalog: itemcatalog.New(), logger: l}
+}
+
+// DB에서 다시 읽어 통째로 교체, 실패하면 이전 것을 그대로 씀
+func (s *CatalogService) Reload(ctx context.Context) error {
+	items, err := s.repo.LoadAll(ctx)
+	if err != nil {
+		return err
+	}
+	s.catalog.Replace(items...)
+	s.logger.Infof("item catalog loaded: %d items", s.catalog.Len())
+	return nil
+}
+
+// 덤프를 지역별 items row에 저장하고 메모리에도 반영
+func (s *CatalogService) Import(ctx context.Context, items []*itemcatalog.Item, regions []bdoapi.Region) error {
+	for _, it := range items {
+		names := make(map[string]string, len(regions))
+		for _, r := range regions {
+			names[r.Code] = it.Name(r.Lang)
+		}
+		if err := s.repo.Save(ctx, it, names); err != nil {
+			return err
+		}
+	}
+	s.catalog.Add(items...)
+	return nil
+}
+
+func (s *CatalogService) Get(id int) (*itemcatalog.Item, error) {
+	return s.catalog.Get(id)
+}
+
+func (s *CatalogService) Search(query string, limit int) []itemcatalog.Match {
+	return s.catalog.Search(query, limit)
+}
+
+// 지역 표시 언어의 이름, 카탈로그에 없으면 빈 값
+func (s *CatalogService) Name(id int, lang string) string {
+	it, err := s.catalog.Get(id)
+	if err != nil {
+		return ""
+	}
+	return it.Name(lang)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"bdo_calc_go/internal/model"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/logger"
+)
+
+// hot list 폴링 (job)
+type HotListTracker struct {
+	client *bdoapi.Client
+	repo   repo.HotListRepo
+	logger logger.Logger
+}
+
+func NewHotListTracker(c *bdoapi.Client, r repo.HotListRepo, l logger.Logger) *HotListTracker {
+	return &HotListTracker{client: c, repo: r, logger: l}
+}
+
+func (s *HotListTracker) Poll(ctx context.Context, now time.Time) (int, error) {
+	list, err := s.client.GetHotList(ctx)
+	if err != nil {
+		return 0, err
+	}
+	region := s.client.Region().Code
+	obs := make([]*model.HotObservation, 0, len(list))
+	for _, h := range list {
+		obs = append(obs, &model.HotObservation{
+			Region:         region,
+			ObservedAt:     now,
+			ItemID:         int(h.ItemID),
+			Enhancement:    int(h.MinEnhance),
+			BasePrice:      h.BasePrice,
+			CurrentStock:   h.CurrentStock,
+			PriceDirection: int(h.PriceDirection),
+			PriceChange:    h.PriceChange,
+		})
+	}
+	// 빈 응답이어도 폴링 시각은 기록 (피드가 이전 스냅샷을 현재로 보지 않게)
+	return len(obs), s.repo.InsertSnapshot(ctx, region, now, obs)
+}
+
+// 보관 기간이 지난 관측 삭제, 지운 수 반환
+func (s *HotListTracker) Prune(ctx context.Context, now time.Time) (int64, error) {
+	return s.repo.DeleteBefore(ctx, s.client.Region().Code, now.Add(-hotRetention))
+}
+
+const (
+	hotLookback  = 7 * 24 * time.Hour
+	hotRetention = 30 * 24 * time.Hour // 피드는 hotLookback만 보지만 분석용으로 한 달 보관
+	// 폴링 간격의 몇 배 동안 안 보였으면 연속 구간이 끊긴 것으로 볼지 (한두 번 놓친 폴링은 허용)
+	hotStreakPolls = 3
+	// 간격을 모를 때 (config 기본값과 같음)
+	defaultHotListInterval = 10 * time.Minute
+)
+
+// /api/v1/hot 피드
+type HotListService struct {
+	repo      repo.HotListRepo
+	streakGap time.Duration
+}
+
+// interval은 get_hot_list_job의 폴링 간격
+func NewHotListService(r repo.HotListRepo, interval time.Duration) *HotListService {
+	if interval <= 0 {
+		interval = defaultHotListInterval
+	}
+	return &HotListService{repo: r, streakGap: hotStreakPolls * interval}
+}
+
+// 가장 최근 스냅샷의 아이템과, 각각 연속으로 핫했던 기간 (오래된 순)
+func (s *HotListService) Feed(ctx context.Context, region string) ([]*model.HotItem, error) {
+	r, err := bdoapi.LookupRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	obs, err := s.repo.ListSince(ctx, r.Code, time.Now().Add(-hotLookback))
+	if err != nil {
+		return nil, err
+	}
+	if len(obs) == 0 {
+		return []*model.HotItem{}, nil
+	}
+	// 마지막 폴링이 빈 응답이면 지금 핫한 아이템 없음
+	latest, err := s.repo.LatestPoll(ctx, r.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ id, enh int }
+	history := map[key][]*model.HotObservation{}
+	for _, o := range obs {
+		k := key{o.ItemID, o.Enhancement}
+		history[k] = append(history[k], o)
+		// hot_list_poll 이전에 쌓인 관측
+		if o.ObservedAt.After(latest) {
+			latest = o.ObservedAt
+		}
+	}
+
+	out := make([]*model.HotItem, 0)
+	for _, hs := range history {
+		last := hs[len(hs)-1]
+		if !last.ObservedAt.Equal(latest) {
+			continue // 지금은 핫하지 않음
+		}
+		// 뒤에서부터 끊기지 않은 구간 찾기
+		start := len(hs) - 1
+		for start > 0 && hs[start].ObservedAt.Sub(hs[start-1].ObservedAt) <= s.streakGap {
+			start--
+		}
+		since := hs[start].ObservedAt
+		out = append(out, &model.HotItem{
+			HotObservation: *last,
+			Since:          since,
+			DurationMin:    int(latest.Sub(since).Minutes()),
+			Observations:   len(hs) - start,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Since.Before(out[j].Since) })
+	return out, nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bdo_calc_go/internal/model"
+)
+
+type memHotListRepo struct {
+	obs   []*model.HotObservation
+	polls []time.Time
+}
+
+func (r *memHotListRepo) InsertSnapshot(_ context.Context, _ string, at time.Time, obs []*model.HotObservation) error {
+	r.polls = append(r.polls, at)
+	r.obs = append(r.obs, obs...)
+	return nil
+}
+
+func (r *memHotListRepo) ListSince(_ context.Context, _ string, since time.Time) ([]*model.HotObservation, error) {
+	var out []*model.HotObservation
+	for _, o := range r.obs {
+		if !o.ObservedAt.Before(since) {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
+
+func (r *memHotListRepo) LatestPoll(context.Context, string) (time.Time, error) {
+	var t time.Time
+	for _, p := range r.polls {
+		if p.After(t) {
+			t = p
+		}
+	}
+	return t, nil
+}
+
+func (r *memHotListRepo) DeleteBefore(_ context.Context, _ string, before time.Time) (int64, error) {
+	var keep []*model.HotObservation
+	for _, o := range r.obs {
+		if !o.ObservedAt.Before(before) {
+			keep = append(keep, o)
+		}
+	}
+	n := len(r.obs) - len(keep)
+	r.obs = keep
+	return int64(n), nil
+}
+
+// at에 폴링해서 ids가 보임, ids가 없으면 빈 응답
+func (r *memHotListRepo) poll(t *testing.T, at time.Time, ids ...int) {
+	t.Helper()
+	obs := make([]*model.HotObservation, 0, len(ids))
+	for _, id := range ids {
+		obs = append(obs, &model.HotObservation{Region: "kr", ObservedAt: at, ItemID: id})
+	}
+	if err := r.InsertSnapshot(context.Background(), "kr", at, obs); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHotListFeed(t *testing.T) {
+	base := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	at := func(min int) time.Time { return base.Add(time.Duration(min) * time.Minute) }
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		polls    func(t *testing.T, r *memHotListRepo)
+		want     map[int]int // item id → 연속 관측 수
+	}{
+		{
+			name:     "streak",
+			interval: 10 * time.Minute,
+			polls: func(t *testing.T, r *memHotListRepo) {
+				r.poll(t, at(0), 1)
+				r.poll(t, at(10), 1, 2)
+				r.poll(t, at(20), 1, 2)
+			},
+			want: map[int]int{1: 3, 2: 2},
+		},
+		{
+			name:     "empty poll clears feed",
+			interval: 10 * time.Minute,
+			polls: func(t *testing.T, r *memHotListRepo) {
+				r.poll(t, at(0), 1)
+				r.poll(t, at(10), 1)
+				r.poll(t, at(20))
+			},
+			want: map[int]int{},
+		},
+		{
+			// 5분 간격이면 20분 공백은 끊긴 것
+			name:     "gap follows interval",
+			interval: 5 * time.Minute,
+			polls: func(t *testing.T, r *memHotListRepo) {
+				r.poll(t, at(0), 1)
+				r.poll(t, at(20), 1)
+				r.poll(t, at(25), 1)
+			},
+			want: map[int]int{1: 2},
+		},
+		{
+			name:     "longer interval keeps streak",
+			interval: 10 * time.Minute,
+			polls: func(t *testing.T, r *memHotListRepo) {
+				r.poll(t, at(0), 1)
+				r.poll(t, at(20), 1)
+				r.poll(t, at(25), 1)
+			},
+			want: map[int]int{1: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &memHotListRepo{}
+			tt.polls(t, r)
+			feed, err := NewHotListService(r, tt.interval).Feed(context.Background(), "kr")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[int]int{}
+			for _, h := range feed {
+				got[h.ItemID] = h.Observations
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("feed = %v, want %v", got, tt.want)
+			}
+			for id, n := range tt.want {
+				if got[id] != n {
+					t.Errorf("item %d: %d observations, want %d", id, got[id], n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"bdo_calc_go/internal/model"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/logger"
+)
+
+type ItemService struct {
+	repo   repo.ItemRepo
+	logger logger.Logger
+}
+
+func NewItemService(r repo.ItemRepo, l logger.Logger) *ItemService {
+	return &ItemService{repo: r, logger: l}
+}
+
+func (s *ItemService) GetByID(ctx context.Context, region string, id int) (*model.Item, error) {
+	r, err := bdoapi.LookupRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, r.Code, id)
+}
+
+// 지역별로 나란히 비교할 때 사용
+func (s *ItemService) ListRegions(ctx context.Context, id int) ([]*model.Item, error) {
+	return s.repo.ListRegions(ctx, id)
+}
+
+// 강화 단계별 하드캡/최근 거래
+func (s *ItemService) ListEnhance(ctx context.Context, region string, id int) ([]*model.ItemEnhance, error) {
+	r, err := bdoapi.LookupRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListEnhance(ctx, r.Code, id)
+}
+
+// (아이템, 강화 단계)별 시계열
+func (s *ItemService) ListEnhanceTS(ctx context.Context, region string, id, enhance int, since time.Time) ([]*model.ItemEnhanceTS, error) {
+	r, err := bdoapi.LookupRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListEnhanceTS(ctx, r.Code, id, enhance, since)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/logger"
+)
+
+// 거래소 실시간 조회 (계산기 화면 등), 지역별 클라이언트를 하나씩 재사용
+type MarketService struct {
+	mu      sync.Mutex
+	clients map[string]*bdoapi.Client
+	opts    []bdoapi.Option
+	logger  logger.Logger
+}
+
+func NewMarketService(l logger.Logger, opts ...bdoapi.Option) *MarketService {
+	return &MarketService{clients: map[string]*bdoapi.Client{}, opts: opts, logger: l}
+}
+
+func (s *MarketService) client(region string) (*bdoapi.Client, error) {
+	r, err := bdoapi.LookupRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.clients[r.Code]
+	if !ok {
+		c = bdoapi.NewClient(append([]bdoapi.Option{bdoapi.WithRegion(r)}, s.opts...)...)
+		s.clients[r.Code] = c
+	}
+	return c, nil
+}
+
+// 지역별 응답 캐시 카운터 (WithCache 없이 만들었으면 전부 0)
+func (s *MarketService) CacheStats() map[string]bdoapi.CacheStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]bdoapi.CacheStats, len(s.clients))
+	for code, c := range s.clients {
+		out[code] = c.CacheStats()
+	}
+	return out
+}
+
+func (s *MarketService) OrderBook(ctx context.Context, region string, id, grade int) (*bdoapi.OrderBook, error) {
+	c, err := s.client(region)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetOrderBook(ctx, id, grade)
+}
+
+func (s *MarketService) Search(ctx context.Context, region string, ids []int) ([]bdoapi.MarketListObject, error) {
+	c, err := s.client(region)
+	if err != nil {
+		return nil, err
+	}
+	return c.SearchMarket(ctx, ids...)
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"bdo_calc_go/internal/model"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/logger"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// 카테고리별 시세 수집 (collector.py의 set_last_trade_price 포팅)
+//  1. 카테고리별 market list로 id/재고/총거래량
+//  2. id별 sub list(최근 거래가), bidding info(구매/판매 가격), 아이템은 워커 workers개가 나눠 처리
+//  3. items 갱신, 이전 사이클 대비 거래량을 item_ts에 적재
+//  4. (enhance 모드) 강화 단계마다 호가 조회, item_enhance_ts에 (item_id, 단계)별로 적재
+type MarketCollector struct {
+	client     *bdoapi.Client
+	repo       repo.ItemRepo
+	logger     logger.Logger
+	categories []bdoapi.Category
+	enhance    EnhanceMode
+	workers    int
+}
+
+// 요청 속도는 클라이언트 리미터를 따르므로 워커 수는 응답 대기 시간만 겹치게 함
+const DefaultCollectWorkers = bdoapi.DefaultSnapshotWorkers
+
+// 강화 단계별 수집 범위
+type EnhanceMode string
+
+const (
+	EnhanceOff  EnhanceMode = "off"  // 0강 호가만
+	EnhanceGear EnhanceMode = "gear" // 강화 카테고리(무기/방어구/장신구 등) 아이템
+	EnhanceAll  EnhanceMode = "all"  // 강화 카테고리 + sub list에 단계가 여러 개인 아이템
+)
+
+func ParseEnhanceMode(s string) (EnhanceMode, error) {
+	switch m := EnhanceMode(s); m {
+	case EnhanceOff, EnhanceGear, EnhanceAll:
+		return m, nil
+	case "":
+		return EnhanceOff, nil
+	}
+	return "", fmt.Errorf("unknown enhance mode %q (off, gear, all)", s)
+}
+
+// workers가 0 이하면 DefaultCollectWorkers
+func NewMarketCollector(c *bdoapi.Client, r repo.ItemRepo, l logger.Logger, categories []bdoapi.Category, enhance EnhanceMode, workers int) *MarketCollector {
+	if workers <= 0 {
+		workers = DefaultCollectWorkers
+	}
+	return &MarketCollector{client: c, repo: r, logger: l, categories: categories, enhance: enhance, workers: workers}
+}
+
+// 한 사이클 수집, 점검/브레이커 open이면 gap만 기록하고 종료
+func (s *MarketCollector) RunCycle(ctx context.Context, now time.Time) error {
+	region := s.client.Region().Code
+	if err := s.client.Available(now); err != nil {
+		return s.skip(ctx, now, err)
+	}
+
+	for _, cat := range s.categories {
+		// 목록을 먼저 다 받고 응답을 닫은 뒤 아이템 수집
+		// (스트림으로 받으면 아이템마다 요청하는 동안 응답이 타임아웃 없이 열려 있음)
+		list, err := s.client.GetMarketListCategory(ctx, cat)
+		if err != nil {
+			if abortCycle(err) {
+				return s.skip(ctx, now, err)
+			}
+			s.logger.Errorf("[%s] category %s skipped: %v", region, cat.Path(), err)
+			continue
+		}
+		if err := s.collectItems(ctx, now, cat, list); err != nil {
+			return s.skip(ctx, now, err)
+		}
+	}
+	return nil
+}
+
+// 목록 아이템을 워커들이 나눠 수집, 사이클을 멈출 에러가 나면 진행 중인 요청도 취소하고 그 에러 반환
+func (s *MarketCollector) collectItems(ctx context.Context, now time.Time, cat bdoapi.Category, list []bdoapi.MarketListObject) error {
+	region := s.client.Region().Code
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(s.workers)
+	for _, m := range list {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			if err := s.collectItem(gctx, now, cat, m); err != nil {
+				if abortCycle(err) {
+					return err
+				}
+				s.logger.Errorf("[%s] item %d skipped: %v", region, m.ItemID, err)
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+func (s *MarketCollector) collectItem(ctx context.Context, now time.Time, cat bdoapi.Category, m bdoapi.MarketListObject) error {
+	region := s.client.Region().Code
+	id := int(m.ItemID)
+
+	subs, err := s.client.GetMarketSubList(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(subs) == 0 {
+		return fmt.Errorf("empty sub list")
+	}
+	book, err := s.client.GetOrderBook(ctx, id, 0)
+	if err != nil {
+		return err
+	}
+
+	prev, err := s.repo.FindByID(ctx, region, id)
+	if err != nil && !errors.Is(err, repo.ErrNotFound) {
+		return err
+	}
+	// 단계별 거래량 계산용, item_enhance를 덮어쓰기 전에 읽어둠
+	levels := bdoapi.EnhanceLevels(subs)
+	trackLevels := s.trackLevels(cat, levels)
+	var prevLevels []*model.ItemEnhance
+	if trackLevels {
+		if prevLevels, err = s.repo.ListEnhance(ctx, region, id); err != nil {
+			return err
+		}
+	}
+
+	for _, sub := range subs {
+		if err := s.repo.UpsertEnhance(ctx, &model.ItemEnhance{
+			Region:          region,
+			ItemID:          id,
+			MinEnhance:      int(sub.MinEnhance),
+			MaxEnhance:      int(sub.MaxEnhance),
+			BasePrice:       sub.BasePrice,
+			StockCount:      sub.CurrentStock,
+			TotalTradeCount: sub.TotalTrades,
+			PriceHardCapMin: sub.MinPriceHardCap,
+			PriceHardCapMax: sub.MaxPriceHardCap,
+			LastTradePrice:  sub.LastTradePrice,
+			LastTradeTime:   sub.LastTradeTime,
+		}); err != nil {
+			return err
+		}
+	}
+
+	sub := subs[0]
+	it := &model.Item{
+		Region:          region,
+		ID:              id,
+		StockCount:      m.CurrentStock,
+		BuyBidPrice:     book.BestAsk(), // 내가 살 때: 판매대기 최저가
+		SellBidPrice:    book.BestBid(), // 내가 팔 때: 구매대기 최고가
+		LastTradePrice:  sub.LastTradePrice,
+		TotalTradeCount: m.TotalTrades,
+		TotalBuyBid:     book.TotalBuy,
+		TotalSellBid:    book.TotalSale,
+	}
+	if err := s.repo.Upsert(ctx, it); err != nil {
+		return err
+	}
+	if trackLevels {
+		if err := s.collectLevels(ctx, now, id, levels, book, prevLevels); err != nil {
+			return err
+		}
+	}
+
+	// 처음 보는 아이템은 주기당 거래량을 알 수 없으므로 다음 사이클부터 적재
+	if prev == nil {
+		return nil
+	}
+	vol := it.TotalTradeCount - prev.TotalTradeCount
+	if vol < 0 {
+		vol = 0
+	}
+	return s.repo.InsertTS(ctx, &model.ItemTS{
+		Region:       region,
+		ItemID:       id,
+		Time:         now,
+		Name:         prev.Name,
+		TradingVol:   vol,
+		TradingPrice: it.LastTradePrice,
+	})
+}
+
+func (s *MarketCollector) trackLevels(cat bdoapi.Category, levels []bdoapi.EnhanceLevel) bool {
+	switch s.enhance {
+	case EnhanceGear:
+		return cat.Enhanceable
+	case EnhanceAll:
+		return cat.Enhanceable || len(levels) > 1
+	}
+	return false
+}
+
+// 0강은 이미 받은 호가를 쓰고 나머지 단계만 조회, 단계 하나가 실패하면 그 단계만 건너뜀
+func (s *MarketCollector) collectLevels(ctx context.Context, now time.Time, id int, levels []bdoapi.EnhanceLevel, book0 *bdoapi.OrderBook, prev []*model.ItemEnhance) error {
+	region := s.client.Region().Code
+	rest := levels
+	if len(levels) > 0 && levels[0].Enhance == 0 {
+		levels[0].OrderBook = book0
+		rest = levels[1:]
+	}
+	if err := s.client.FillEnhanceLevels(ctx, id, rest, bdoapi.EnhanceOptions{OrderBooks: true}); err != nil {
+		return err
+	}
+
+	prevTrades := make(map[int]int64, len(prev))
+	for _, p := range prev {
+		prevTrades[p.MinEnhance] = p.TotalTradeCount
+	}
+	rows := make([]*model.ItemEnhanceTS, 0, len(levels))
+	for _, lv := range levels {
+		if lv.Err != nil {
+			if abortCycle(lv.Err) {
+				return lv.Err
+			}
+			s.logger.Errorf("[%s] item %d +%d skipped: %v", region, id, lv.Enhance, lv.Err)
+			continue
+		}
+		ts := &model.ItemEnhanceTS{
+			Region:         region,
+			ItemID:         id,
+			Enhance:        lv.Enhance,
+			MaxEnhance:     lv.MaxEnhance,
+			Time:           now,
+			BasePrice:      lv.Sub.BasePrice,
+			StockCount:     lv.Sub.CurrentStock,
+			LastTradePrice: lv.Sub.LastTradePrice,
+			BuyBidPrice:    lv.OrderBook.BestAsk(),
+			SellBidPrice:   lv.OrderBook.BestBid(),
+			TotalBuyBid:    lv.OrderBook.TotalBuy,
+			TotalSellBid:   lv.OrderBook.TotalSale,
+		}
+		// 처음 보는 단계는 거래량을 알 수 없음 (null)
+		if total, ok := prevTrades[lv.Enhance]; ok {
+			vol := max(lv.Sub.TotalTrades-total, 0)
+			ts.TradingVol = &vol
+		}
+		rows = append(rows, ts)
+	}
+	return s.repo.InsertEnhanceTS(ctx, rows)
+}
+
+func (s *MarketCollector) skip(ctx context.Context, now time.Time, cause error) error {
+	region := s.client.Region().Code
+	s.logger.Infof("[%s] cycle %s skipped: %v", region, now.Format(time.RFC3339), cause)
+	if ctx.Err() != nil {
+		return cause
+	}
+	if err := s.repo.InsertGap(ctx, &model.CollectGap{Region: region, Time: now, Reason: cause.Error()}); err != nil {
+		return fmt.Errorf("insert gap: %w", err)
+	}
+	return nil
+}
+
+// 사이클 전체를 멈춰야 하는 에러 (나머지는 아이템 단위로 스킵)
+func abortCycle(err error) bool {
+	return errors.Is(err, bdoapi.ErrMaintenance) ||
+		errors.Is(err, bdoapi.ErrCircuitOpen) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"bdo_calc_go/internal/model"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+)
+
+// 수집 결과만 보관하는 ItemRepo
+type memItemRepo struct {
+	mu       sync.Mutex
+	items    map[string]*model.Item
+	enhance  map[string]*model.ItemEnhance
+	ts       []*model.ItemTS
+	gaps     []*model.CollectGap
+	enhances []*model.ItemEnhanceTS
+	backfill []*model.ItemTS
+	filled   map[string]bool
+}
+
+func newMemItemRepo() *memItemRepo {
+	return &memItemRepo{items: map[string]*model.Item{}, enhance: map[string]*model.ItemEnhance{}, filled: map[string]bool{}}
+}
+
+func itemKey(region string, id int) string { return fmt.Sprintf("%s/%d", region, id) }
+
+func (r *memItemRepo) Upsert(_ context.Context, it *model.Item) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *it
+	r.items[itemKey(it.Region, it.ID)] = &cp
+	return nil
+}
+
+func (r *memItemRepo) FindByID(_ context.Context, region string, id int) (*model.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	it, ok := r.items[itemKey(region, id)]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	cp := *it
+	return &cp, nil
+}
+
+func (r *memItemRepo) ListRegions(context.Context, int) ([]*model.Item, error) { return nil, nil }
+
+func (r *memItemRepo) InsertTS(_ context.Context, ts *model.ItemTS) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ts = append(r.ts, ts)
+	return nil
+}
+
+func (r *memItemRepo) InsertGap(_ context.Context, gap *model.CollectGap) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.gaps = append(r.gaps, gap)
+	return nil
+}
+
+func (r *memItemRepo) UpsertEnhance(_ context.Context, e *model.ItemEnhance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp := *e
+	r.enhance[fmt.Sprintf("%s/%d/%d", e.Region, e.ItemID, e.MinEnhance)] = &cp
+	return nil
+}
+
+func (r *memItemRepo) ListEnhance(_ context.Context, region string, id int) ([]*model.ItemEnhance, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*model.ItemEnhance
+	for _, e := range r.enhance {
+		if e.Region == region && e.ItemID == id {
+			cp := *e
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (r *memItemRepo) InsertEnhanceTS(_ context.Context, rows []*model.ItemEnhanceTS) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enhances = append(r.enhances, rows...)
+	return nil
+}
+
+func (r *memItemRepo) ListEnhanceTS(context.Context, string, int, int, time.Time) ([]*model.ItemEnhanceTS, error) {
+	return nil, nil
+}
+
+func (r *memItemRepo) ListNotBackfilled(_ context.Context, region string) ([]*model.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*model.Item
+	for k, it := range r.items {
+		if it.Region == region && !r.filled[k] {
+			cp := *it
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (r *memItemRepo) InsertBackfillTS(_ context.Context, region string, id int, rows []*model.ItemTS) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.backfill = append(r.backfill, rows...)
+	r.filled[itemKey(region, id)] = true
+	return nil
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+
+// 점검 시간이 아닌 시각 (월요일)
+var collectAt = time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC)
+
+func newTestCollector(t *testing.T, m *fakemarket.Market, opts ...bdoapi.Option) (*MarketCollector, *memItemRepo) {
+	t.Helper()
+	srv := fakemarket.Start(m)
+	t.Cleanup(srv.Close)
+	c := bdoapi.NewClient(append([]bdoapi.Option{
+		bdoapi.WithBaseURL(srv.URL + "/Trademarket/"),
+		bdoapi.WithRetryPolicy(bdoapi.NoRetry),
+		bdoapi.WithRateLimit(0, 1),
+	}, opts...)...)
+	cat, err := bdoapi.Categories().Lookup("material.blood")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newMemItemRepo()
+	return NewMarketCollector(c, r, nopLogger{}, []bdoapi.Category{cat}, EnhanceOff, 0), r
+}
+
+func TestMarketCollectorRunCycle(t *testing.T) {
+	m := fakemarket.Demo()
+	s, r := newTestCollector(t, m)
+	ctx := context.Background()
+
+	// 첫 사이클: items만, 거래량은 다음 사이클부터
+	if err := s.RunCycle(ctx, collectAt); err != nil {
+		t.Fatal(err)
+	}
+	it, err := r.FindByID(ctx, bdoapi.DefaultRegion, 6201)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := m.Item(6201)
+	if it.StockCount != want.Levels[0].Stock || it.BuyBidPrice != want.Levels[0].BasePrice {
+		t.Errorf("item = %+v", it)
+	}
+	if len(r.ts) != 0 {
+		t.Errorf("first cycle wrote %d item_ts rows", len(r.ts))
+	}
+
+	// 거래 후 두 번째 사이클: 차이만큼 거래량
+	if err := m.Trade(6201, 0, 250, 1300, collectAt); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RunCycle(ctx, collectAt.Add(10*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	var got *model.ItemTS
+	for _, ts := range r.ts {
+		if ts.ItemID == 6201 {
+			got = ts
+		}
+	}
+	if got == nil || got.TradingVol != 250 || got.TradingPrice != 1300 {
+		t.Errorf("item_ts 6201 = %+v, want vol 250 price 1300", got)
+	}
+}
+
+// 아이템 하나가 실패해도 나머지는 수집
+func TestMarketCollectorSkipsFailedItem(t *testing.T) {
+	m := fakemarket.Demo()
+	s, r := newTestCollector(t, m)
+	m.InjectFault("GetBiddingInfoList", fakemarket.Fault{Status: http.StatusInternalServerError, Times: 1})
+
+	if err := s.RunCycle(context.Background(), collectAt); err != nil {
+		t.Fatal(err)
+	}
+	list, err := s.client.GetMarketList(context.Background(), "material.blood")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(r.items), len(list)-1; got != want {
+		t.Errorf("collected %d items, want %d", got, want)
+	}
+	if len(r.gaps) != 0 {
+		t.Errorf("gaps = %+v", r.gaps)
+	}
+}
+
+// 아이템 요청은 워커 수만큼 겹쳐서 보냄
+func TestMarketCollectorConcurrent(t *testing.T) {
+	m := fakemarket.Demo()
+	s, r := newTestCollector(t, m)
+	list, err := s.client.GetMarketList(context.Background(), "material.blood")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const latency = 20 * time.Millisecond
+	m.SetLatency(latency)
+
+	begin := time.Now()
+	if err := s.RunCycle(context.Background(), collectAt); err != nil {
+		t.Fatal(err)
+	}
+	elapsed := time.Since(begin)
+	if len(r.items) != len(list) {
+		t.Fatalf("collected %d items, want %d", len(r.items), len(list))
+	}
+	// 순서대로면 아이템마다 sub list + 호가 두 번
+	serial := time.Duration(len(list)*2) * latency
+	if elapsed > serial/2 {
+		t.Errorf("cycle took %s, serial would be %s", elapsed, serial)
+	}
+}
+
+// 점검(503)이면 사이클을 멈추고 gap만 기록
+func TestMarketCollectorMaintenance(t *testing.T) {
+	m := fakemarket.Demo()
+	s, r := newTestCollector(t, m)
+	m.InjectFault("GetWorldMarketSubList", fakemarket.Fault{Status: http.StatusServiceUnavailable})
+
+	if err := s.RunCycle(context.Background(), collectAt); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.gaps) != 1 || len(r.items) != 0 {
+		t.Errorf("gaps = %d, items = %d, want 1 gap and no items", len(r.gaps), len(r.items))
+	}
+	// 이미 보낸 요청(워커 수)까지만, 나머지 아이템은 시작하지 않음
+	if got := m.Requests("GetWorldMarketSubList"); got > s.workers {
+		t.Errorf("sub list requests = %d, want at most %d (cycle should stop)", got, s.workers)
+	}
+}
+
+// 점검 시간표에 걸리면 요청 없이 gap
+func TestMarketCollectorScheduledMaintenance(t *testing.T) {
+	m := fakemarket.Demo()
+	thu := time.Date(2026, 3, 5, 2, 0, 0, 0, time.UTC) // 목요일 11시 KST
+	s, r := newTestCollector(t, m, bdoapi.WithMaintenanceCalendar(bdoapi.MaintenanceCalendar{
+		bdoapi.DefaultRegion: {{Weekday: time.Thursday, Start: 10 * time.Hour, End: 12 * time.Hour}},
+	}))
+
+	if err := s.RunCycle(context.Background(), thu); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.gaps) != 1 {
+		t.Errorf("gaps = %d, want 1", len(r.gaps))
+	}
+	if got := m.Requests("GetWorldMarketList"); got != 0 {
+		t.Errorf("list requests = %d, want 0", got)
+	}
+}
+
+// 단계별 수집: off는 0강만, gear는 강화 카테고리, all은 단계가 여러 개인 재료까지
+func TestMarketCollectorEnhance(t *testing.T) {
+	const ring, potion = 11607, 99200
+	tests := []struct {
+		mode EnhanceMode
+		want map[int]int // 아이템별 item_enhance_ts 행 수
+	}{
+		{EnhanceOff, map[int]int{}},
+		{EnhanceGear, map[int]int{ring: 6}},
+		{EnhanceAll, map[int]int{ring: 6, potion: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			m := fakemarket.Demo()
+			rings := make([]*fakemarket.Level, 6)
+			for i := range rings {
+				rings[i] = &fakemarket.Level{Enhance: int8(i), BasePrice: int64(i+1) * 1_000_000_000, Stock: int64(10 - i), TotalTrades: int64(100 * (i + 1))}
+			}
+			m.AddItem(fakemarket.Item{ID: ring, Name: "ring", MainCategory: 20, SubCategory: 1, Levels: rings})
+			blood, err := bdoapi.Categories().Lookup("material.blood")
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.AddItem(fakemarket.Item{ID: potion, Name: "potion", MainCategory: blood.MainID, SubCategory: blood.SubID, Levels: []*fakemarket.Level{
+				{Enhance: 0, BasePrice: 500, Stock: 10, TotalTrades: 1000},
+				{Enhance: 1, BasePrice: 2000, Stock: 3, TotalTrades: 50},
+			}})
+			if err := m.SetOrders(ring, 3, []bdoapi.BiddingOrder{{Price: 3_900_000_000, Buy: 2}, {Price: 4_100_000_000, Sale: 1}}); err != nil {
+				t.Fatal(err)
+			}
+
+			s, r := newTestCollector(t, m)
+			cat, err := bdoapi.Categories().Lookup("accessory.ring")
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.categories = append(s.categories, cat)
+			s.enhance = tt.mode
+			ctx := context.Background()
+
+			// 첫 사이클: 처음 보는 단계라 거래량 없음
+			if err := s.RunCycle(ctx, collectAt); err != nil {
+				t.Fatal(err)
+			}
+			got := map[int]int{}
+			for _, ts := range r.enhances {
+				got[ts.ItemID]++
+				if ts.TradingVol != nil {
+					t.Errorf("first cycle %d +%d vol = %d, want nil", ts.ItemID, ts.Enhance, *ts.TradingVol)
+				}
+				if ts.ItemID != ring {
+					continue
+				}
+				price := int64(ts.Enhance+1) * 1_000_000_000
+				wantAsk, wantBid := price, int64(0)
+				if ts.Enhance == 3 {
+					wantAsk, wantBid = 4_100_000_000, 3_900_000_000
+				}
+				if ts.BasePrice != price || ts.StockCount != int64(10-ts.Enhance) || ts.BuyBidPrice != wantAsk || ts.SellBidPrice != wantBid {
+					t.Errorf("ring +%d = %+v", ts.Enhance, ts)
+				}
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Fatalf("enhance rows = %v, want %v", got, tt.want)
+			}
+			if tt.mode == EnhanceOff {
+				return
+			}
+
+			// 두 번째 사이클: 거래가 있던 단계만 거래량
+			if err := m.Trade(ring, 3, 2, 4_000_000_000, collectAt); err != nil {
+				t.Fatal(err)
+			}
+			r.enhances = nil
+			if err := s.RunCycle(ctx, collectAt.Add(10*time.Minute)); err != nil {
+				t.Fatal(err)
+			}
+			if n := len(r.enhances); n != tt.want[ring]+tt.want[potion] {
+				t.Fatalf("second cycle wrote %d enhance rows", n)
+			}
+			for _, ts := range r.enhances {
+				var want int64
+				if ts.ItemID == ring && ts.Enhance == 3 {
+					want = 2
+				}
+				if ts.TradingVol == nil || *ts.TradingVol != want {
+					t.Errorf("%d +%d vol = %v, want %d", ts.ItemID, ts.Enhance, ts.TradingVol, want)
+				}
+			}
+		})
+	}
+}
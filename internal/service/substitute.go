@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"bdo_calc_go/internal/model"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/logger"
+)
+
+var ErrUnknownGroup = errors.New("unknown item group")
+
+// 그룹 멤버를 비교할 가격 기준 (items 테이블 컬럼)
+type PriceSource string
+
+// 이름은 호가 쪽 기준, items 컬럼은 "내가 살 때/팔 때" 기준이라 서로 엇갈림
+const (
+	PriceSellBid   PriceSource = "sell_bid" // 판매 등록 최저가(BestAsk), 바로 살 수 있는 가격 → items.buy_bid_price
+	PriceBuyBid    PriceSource = "buy_bid"  // 구매 등록 최고가(BestBid) → items.sell_bid_price
+	PriceLastTrade PriceSource = "last_trade"
+)
+
+func ParsePriceSource(s string) (PriceSource, error) {
+	switch p := PriceSource(s); p {
+	case PriceSellBid, PriceBuyBid, PriceLastTrade:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown price source %q", s)
+}
+
+func (p PriceSource) price(it *model.Item) int64 {
+	switch p {
+	case PriceBuyBid:
+		return it.SellBidPrice
+	case PriceLastTrade:
+		return it.LastTradePrice
+	}
+	return it.BuyBidPrice
+}
+
+// 기존 파이썬 수집기 기준 (재고 10000 초과)
+const DefaultSubstituteMinStock = 10000
+
+// 아이템 그룹별 가장 싼 대체 아이템 고르기 (job, 수집 사이클 뒤에 실행)
+type SubstituteSelector struct {
+	items    repo.ItemRepo
+	repo     repo.SubstituteRepo
+	catalog  *CatalogService // 아이템 이름
+	logger   logger.Logger
+	minStock int64
+	source   PriceSource
+}
+
+func NewSubstituteSelector(items repo.ItemRepo, r repo.SubstituteRepo, catalog *CatalogService, l logger.Logger, minStock int64, source PriceSource) *SubstituteSelector {
+	return &SubstituteSelector{items: items, repo: r, catalog: catalog, logger: l, minStock: minStock, source: source}
+}
+
+// 재고가 minStock보다 많은 멤버 중 가장 싼 것, 없으면 그룹의 첫 번째 아이템
+func (s *SubstituteSelector) Run(ctx context.Context, region string, now time.Time) ([]*model.SubstituteChoice, error) {
+	groups := bdoapi.ItemGroups()
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]*model.SubstituteChoice, 0, len(names))
+	for _, name := range names {
+		c, err := s.choose(ctx, region, name, groups[name], now)
+		if err != nil {
+			return nil, fmt.Errorf("group %s: %w", name, err)
+		}
+		if c == nil {
+			s.logger.Errorf("[%s] group %s: no price data", region, name)
+			continue
+		}
+		out = append(out, c)
+	}
+	return out, s.repo.Insert(ctx, out)
+}
+
+func (s *SubstituteSelector) choose(ctx context.Context, region, group string, members []int, now time.Time) (*model.SubstituteChoice, error) {
+	var best, first *model.Item
+	for _, id := range members {
+		it, err := s.items.FindByID(ctx, region, id)
+		if errors.Is(err, repo.ErrNotFound) {
+			continue // 아직 수집 안 된 아이템
+		}
+		if err != nil {
+			return nil, err
+		}
+		// 조건에 맞는 게 없으면 실제로 찾은 첫 멤버로
+		if first == nil {
+			first = it
+		}
+		p := s.source.price(it)
+		if it.StockCount <= s.minStock || p <= 0 {
+			continue
+		}
+		if best == nil || p < s.source.price(best) {
+			best = it
+		}
+	}
+
+	fallback := best == nil
+	if fallback {
+		if first == nil {
+			return nil, nil
+		}
+		best = first
+	}
+	return &model.SubstituteChoice{
+		Region:   region,
+		Group:    group,
+		ChosenAt: now,
+		ItemID:   best.ID,
+		Name:     s.itemName(region, best),
+		Price:    s.source.price(best),
+		Stock:    best.StockCount,
+		Source:   string(s.source),
+		Fallback: fallback,
+	}, nil
+}
+
+// 카탈로그의 지역 언어 이름, 카탈로그에 없으면 items row의 이름
+func (s *SubstituteSelector) itemName(region string, it *model.Item) string {
+	r, err := bdoapi.LookupRegion(region)
+	if s.catalog == nil || err != nil {
+		return it.Name
+	}
+	if name := s.catalog.Name(it.ID, r.Lang); name != "" {
+		return name
+	}
+	return it.Name
+}
+
+// /api/v1/substitutes 조회
+type SubstituteService struct {
+	repo repo.SubstituteRepo
+}
+
+func NewSubstituteService(r repo.SubstituteRepo) *SubstituteService {
+	return &SubstituteService{repo: r}
+}
+
+func (s *SubstituteService) Latest(ctx context.Context, region string) ([]*model.SubstituteChoice, error) {
+	r, err := bdoapi.LookupRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Latest(ctx, r.Code)
+}
+
+func (s *SubstituteService) History(ctx context.Context, region, group string, since time.Time) ([]*model.SubstituteChoice, error) {
+	r, err := bdoapi.LookupRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := bdoapi.ItemGroups()[group]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownGroup, group)
+	}
+	return s.repo.History(ctx, r.Code, group, since)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"bdo_calc_go/internal/model"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+)
+
+func TestSubstituteChoose(t *testing.T) {
+	ctx := context.Background()
+	r := newMemItemRepo()
+	for _, it := range []*model.Item{
+		{Region: "kr", ID: 2, Name: "b", BuyBidPrice: 900, StockCount: 5},
+		{Region: "kr", ID: 3, Name: "c", BuyBidPrice: 1200, StockCount: 50000},
+		{Region: "kr", ID: 4, Name: "d", BuyBidPrice: 1000, StockCount: 50000},
+		{Region: "kr", ID: 5, Name: "e", BuyBidPrice: 0, StockCount: 50000},
+	} {
+		r.Upsert(ctx, it)
+	}
+	s := NewSubstituteSelector(r, nil, nil, nopLogger{}, DefaultSubstituteMinStock, PriceSellBid)
+
+	tests := []struct {
+		name         string
+		members      []int
+		want         int // 0이면 선택 없음
+		wantFallback bool
+	}{
+		{name: "cheapest in stock", members: []int{2, 3, 4}, want: 4},
+		{name: "skips no price", members: []int{5, 3}, want: 3},
+		// 1은 아직 수집 전, 실제로 찾은 첫 멤버(2)로 대체
+		{name: "fallback to first found", members: []int{1, 2, 5}, want: 2, wantFallback: true},
+		{name: "none collected", members: []int{1, 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := s.choose(ctx, "kr", "g", tt.members, collectAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == 0 {
+				if c != nil {
+					t.Fatalf("choice = %+v, want none", c)
+				}
+				return
+			}
+			if c == nil || c.ItemID != tt.want || c.Fallback != tt.wantFallback {
+				t.Errorf("choice = %+v, want item %d fallback %v", c, tt.want, tt.wantFallback)
+			}
+		})
+	}
+}
+
+// 호가창 양쪽이 다를 때 가격 기준별로 어느 쪽을 비교하는지
+func TestSubstituteChooseOrderBookSide(t *testing.T) {
+	m := fakemarket.Demo()
+	members := bdoapi.ItemGroups()["deer"][:2]
+	a, b := int64(members[0]), int64(members[1])
+	// a: 판매 1000 / 구매 500, b: 판매 800 / 구매 900
+	if err := m.SetOrders(a, 0, []bdoapi.BiddingOrder{{Price: 500, Buy: 10}, {Price: 1000, Sale: 10}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetOrders(b, 0, []bdoapi.BiddingOrder{{Price: 800, Sale: 10}, {Price: 900, Buy: 10}}); err != nil {
+		t.Fatal(err)
+	}
+	c, r := newTestCollector(t, m)
+	ctx := context.Background()
+	if err := c.RunCycle(ctx, collectAt); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		source    PriceSource
+		want      int
+		wantPrice int64
+	}{
+		{PriceSellBid, int(b), 800}, // 판매 최저가가 더 싼 b
+		{PriceBuyBid, int(a), 500},  // 구매 최고가가 더 낮은 a
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.source), func(t *testing.T) {
+			s := NewSubstituteSelector(r, nil, nil, nopLogger{}, 0, tt.source)
+			got, err := s.choose(ctx, bdoapi.DefaultRegion, "deer", members[:2], collectAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got == nil || got.ItemID != tt.want || got.Price != tt.wantPrice {
+				t.Errorf("choice = %+v, want item %d at %d", got, tt.want, tt.wantPrice)
+			}
+		})
+	}
+}
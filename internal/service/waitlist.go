@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"bdo_calc_go/internal/model"
+	"bdo_calc_go/internal/repo"
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/logger"
+)
+
+// 등록 대기 매물 추적 (job에서 주기적으로 Poll)
+type WaitListTracker struct {
+	client *bdoapi.Client
+	repo   repo.WaitListRepo
+	logger logger.Logger
+}
+
+func NewWaitListTracker(c *bdoapi.Client, r repo.WaitListRepo, l logger.Logger) *WaitListTracker {
+	return &WaitListTracker{client: c, repo: r, logger: l}
+}
+
+// 현재 대기 목록을 저장, 저장한 묶음 수 반환
+func (s *WaitListTracker) Poll(ctx context.Context, now time.Time) (int, error) {
+	list, err := s.client.GetWaitList(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// 같은 매물 여러 개는 수량으로 묶음
+	type key struct {
+		id, enh int
+		price   int64
+		liveAt  int64
+	}
+	entries := map[key]*model.WaitListEntry{}
+	order := make([]key, 0, len(list))
+	for _, w := range list {
+		k := key{int(w.ItemID), int(w.Enhancement), w.Price, w.LiveAt.Unix()}
+		if e, ok := entries[k]; ok {
+			e.Quantity++
+			continue
+		}
+		entries[k] = &model.WaitListEntry{
+			Region:      s.client.Region().Code,
+			ItemID:      int(w.ItemID),
+			Enhancement: int(w.Enhancement),
+			Price:       w.Price,
+			LiveAt:      w.LiveAt,
+			Quantity:    1,
+			LastSeen:    now,
+		}
+		order = append(order, k)
+	}
+
+	for _, k := range order {
+		if err := s.repo.Upsert(ctx, entries[k]); err != nil {
+			return 0, err
+		}
+	}
+	return len(order), nil
+}
+
+// API 조회용
+type WaitListService struct {
+	repo repo.WaitListRepo
+}
+
+func NewWaitListService(r repo.WaitListRepo) *WaitListService {
+	return &WaitListService{repo: r}
+}
+
+// 최근 window 동안 보인 매물 (아직 대기 중 + 최근에 풀린 것)
+func (s *WaitListService) Recent(ctx context.Context, region string, window time.Duration) ([]*model.WaitListEntry, error) {
+	r, err := bdoapi.LookupRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListSeenSince(ctx, r.Code, time.Now().Add(-window))
+}
@@ -6,7 +6,7 @@
 -- 6hours
 SELECT time, value1, value2
 FROM item_ts
-WHERE item_id = $1 AND time >= now() - interval '6 hours'
+WHERE region = $1 AND item_id = $2 AND time >= now() - interval '6 hours'
 ORDER BY time;
 
 -- 1day
@@ -16,7 +16,7 @@ SELECT
   avg(value1) AS avg_v1,
   avg(value2) AS avg_v2
 FROM item_ts
-WHERE item_id = $1
+WHERE region = $1 AND item_id = $2
   AND time >= now() - interval '1 day'   -- 필요에 따라 7 days/30 days 등으로 변경
 GROUP BY bucket
 ORDER BY bucket;
@@ -27,7 +27,7 @@ SELECT
   avg(value1) AS avg_v1,
   avg(value2) AS avg_v2
 FROM item_ts
-WHERE item_id = $1
+WHERE region = $1 AND item_id = $2
   AND time >= now() - interval '7 days'
 GROUP BY bucket
 ORDER BY bucket;
@@ -39,7 +39,7 @@ SELECT
   avg(value1) AS avg_v1,
   avg(value2) AS avg_v2
 FROM item_ts
-WHERE item_id = $1
+WHERE region = $1 AND item_id = $2
   AND time >= now() - interval '30 days'
 GROUP BY bucket
 ORDER BY bucket;
\ No newline at end of file
@@ -1,36 +1,231 @@
 -- internal/sql/schema.sql
 /*
- *
+ * 스키마는 이 파일 하나로 관리 (psql -f internal/sql/schema.sql)
+ * 여러 번 실행해도 되고, 이전 버전 스키마로 만든 DB도 이 파일로 올림
  */
 
 SET lock_timeout = '5s';
 SET statement_timeout = '30s';
 SET TIME ZONE 'Asia/Seoul';
 
-
+-- 가격/수량은 고가 장비가 int 범위를 넘을 수 있어 bigint (item_enhance와 같음)
 CREATE TABLE IF NOT EXISTS items (
-  item_id           TEXT PRIMARY KEY,
+  region            TEXT        NOT NULL DEFAULT 'kr',
+  item_id           int         NOT NULL,
   item_attrs        jsonb,
   name              TEXT        NOT NULL,
-  stock_count       int,
-  buy_bid_price     int,
-  sell_bid_price    int,
-  last_trade_price  int,
-  total_trade_count int,
-  total_buy_bid     int,
-  total_sell_bid    int,
-  PRIMARY KEY item_id
+  stock_count       bigint,
+  buy_bid_price     bigint,
+  sell_bid_price    bigint,
+  last_trade_price  bigint,
+  total_trade_count bigint,
+  total_buy_bid     bigint,
+  total_sell_bid    bigint,
+  PRIMARY KEY (region, item_id)
 );
 
-CREATE TABLE public.item_ts (
+-- 이전 스키마로 만든 items 맞추기 (CREATE TABLE IF NOT EXISTS로는 기존 테이블이 안 바뀜)
+--  - 예전 repo.Migrate: (id text PRIMARY KEY, name, updated_at) → id를 item_id로
+--  - 예전 schema.sql: item_id text → int (숫자가 아닌 id가 있으면 여기서 실패하므로 먼저 정리할 것)
+-- region/PK는 아래 ensure_region_pk에서
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM information_schema.columns
+             WHERE table_schema = 'public' AND table_name = 'items' AND column_name = 'id')
+     AND NOT EXISTS (SELECT 1 FROM information_schema.columns
+                     WHERE table_schema = 'public' AND table_name = 'items' AND column_name = 'item_id') THEN
+    ALTER TABLE public.items RENAME COLUMN id TO item_id;
+  END IF;
+
+  IF (SELECT data_type FROM information_schema.columns
+      WHERE table_schema = 'public' AND table_name = 'items' AND column_name = 'item_id') = 'text' THEN
+    ALTER TABLE public.items ALTER COLUMN item_id TYPE int USING item_id::int;
+  END IF;
+
+  -- 예전 Migrate의 updated_at은 더 이상 안 씀, upsert가 실패하지 않게 기본값만
+  IF EXISTS (SELECT 1 FROM information_schema.columns
+             WHERE table_schema = 'public' AND table_name = 'items' AND column_name = 'updated_at') THEN
+    ALTER TABLE public.items ALTER COLUMN updated_at SET DEFAULT now();
+  END IF;
+END$$;
+
+ALTER TABLE items
+  ADD COLUMN IF NOT EXISTS item_attrs        jsonb,
+  ADD COLUMN IF NOT EXISTS stock_count       bigint,
+  ADD COLUMN IF NOT EXISTS buy_bid_price     bigint,
+  ADD COLUMN IF NOT EXISTS sell_bid_price    bigint,
+  ADD COLUMN IF NOT EXISTS last_trade_price  bigint,
+  ADD COLUMN IF NOT EXISTS total_trade_count bigint,
+  ADD COLUMN IF NOT EXISTS total_buy_bid     bigint,
+  ADD COLUMN IF NOT EXISTS total_sell_bid    bigint,
+  -- 가격 기록으로 item_ts를 채운 시각 (backfill_price_job), NULL이면 아직
+  ADD COLUMN IF NOT EXISTS backfilled_at     timestamptz;
+
+-- sub list 레코드(강화 단계)별 현재 상태, 가격은 하드캡이 int 범위를 넘을 수 있어 bigint
+CREATE TABLE IF NOT EXISTS item_enhance (
+  region            text        NOT NULL DEFAULT 'kr',
+  item_id           int         NOT NULL,
+  min_enhance       smallint    NOT NULL,
+  max_enhance       smallint    NOT NULL,
+  base_price        bigint,
+  stock_count       bigint,
+  total_trade_count bigint,
+  price_hardcap_min bigint,
+  price_hardcap_max bigint,
+  last_trade_price  bigint,
+  last_trade_time   timestamptz,
+  PRIMARY KEY (region, item_id, min_enhance)
+);
+
+-- 지역별로 같은 아이템을 따로 쌓음
+CREATE TABLE IF NOT EXISTS public.item_ts (
+  region        text        NOT NULL DEFAULT 'kr',
   item_id       int         NOT NULL,
   time          timestamptz NOT NULL,
   name          text,
-  trading_vol   int,
-  trading_price int,
-  PRIMARY KEY (item_id, time)
+  trading_vol   bigint,
+  trading_price bigint,
+  PRIMARY KEY (region, item_id, time)
 ) PARTITION BY RANGE (time);
 
+-- 강화 단계별 시계열 (collector -enhance), item_ts와 같은 월 파티션
+CREATE TABLE IF NOT EXISTS public.item_enhance_ts (
+  region           text        NOT NULL DEFAULT 'kr',
+  item_id          int         NOT NULL,
+  enhance          smallint    NOT NULL,
+  time             timestamptz NOT NULL,
+  max_enhance      smallint    NOT NULL,
+  base_price       bigint,
+  stock_count      bigint,
+  trading_vol      bigint,
+  last_trade_price bigint,
+  buy_bid_price    bigint,
+  sell_bid_price   bigint,
+  total_buy_bid    bigint,
+  total_sell_bid   bigint,
+  PRIMARY KEY (region, item_id, enhance, time)
+) PARTITION BY RANGE (time);
+
+-- region 컬럼 이전에 만든 DB 마이그레이션 (CREATE TABLE IF NOT EXISTS로는 기존 테이블이 안 바뀜)
+-- 컬럼이 없으면 추가하면서 기존 row는 'kr'로 채우고, PK가 pk_cols가 아니면 교체
+-- 파티션 테이블(item_ts)은 부모에만 하면 파티션에도 반영됨
+CREATE OR REPLACE FUNCTION public.ensure_region_pk(base_table regclass, pk_cols text[])
+RETURNS void
+LANGUAGE plpgsql
+AS $fn$
+DECLARE
+  pk_name text;
+  cur_pk  text[];
+BEGIN
+  EXECUTE format('ALTER TABLE %s ADD COLUMN IF NOT EXISTS region text NOT NULL DEFAULT %L', base_table, 'kr');
+
+  SELECT c.conname, array_agg(a.attname::text ORDER BY k.n)
+    INTO pk_name, cur_pk
+  FROM pg_constraint c
+  CROSS JOIN LATERAL unnest(c.conkey) WITH ORDINALITY AS k(attnum, n)
+  JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+  WHERE c.conrelid = base_table AND c.contype = 'p'
+  GROUP BY c.conname;
+
+  IF cur_pk IS DISTINCT FROM pk_cols THEN
+    IF pk_name IS NOT NULL THEN
+      EXECUTE format('ALTER TABLE %s DROP CONSTRAINT %I', base_table, pk_name);
+    END IF;
+    EXECUTE format('ALTER TABLE %s ADD PRIMARY KEY (%s)', base_table,
+                   (SELECT string_agg(quote_ident(col), ', ') FROM unnest(pk_cols) AS col));
+  END IF;
+END
+$fn$;
+
+SELECT public.ensure_region_pk('public.items', ARRAY['region', 'item_id']);
+SELECT public.ensure_region_pk('public.item_ts', ARRAY['region', 'item_id', 'time']);
+
+-- int로 만든 가격/수량 컬럼을 bigint로 (이미 bigint면 건드리지 않음, 파티션 테이블은 부모에만)
+CREATE OR REPLACE FUNCTION public.ensure_bigint(base_table regclass, cols text[])
+RETURNS void
+LANGUAGE plpgsql
+AS $fn$
+DECLARE
+  col text;
+BEGIN
+  FOREACH col IN ARRAY cols LOOP
+    IF EXISTS (SELECT 1 FROM pg_attribute
+               WHERE attrelid = base_table AND attname = col AND NOT attisdropped
+                 AND atttypid = 'integer'::regtype) THEN
+      EXECUTE format('ALTER TABLE %s ALTER COLUMN %I TYPE bigint', base_table, col);
+    END IF;
+  END LOOP;
+END
+$fn$;
+
+SELECT public.ensure_bigint('public.items', ARRAY['stock_count', 'buy_bid_price', 'sell_bid_price',
+  'last_trade_price', 'total_trade_count', 'total_buy_bid', 'total_sell_bid']);
+SELECT public.ensure_bigint('public.item_ts', ARRAY['trading_vol', 'trading_price']);
+
+-- 등록 대기 매물, 폴링마다 last_seen 갱신 (live_at 이후 사라지면 거래소에 풀린 것)
+CREATE TABLE IF NOT EXISTS wait_list (
+  region      text        NOT NULL,
+  item_id     int         NOT NULL,
+  enhancement smallint    NOT NULL,
+  price       bigint      NOT NULL,
+  live_at     timestamptz NOT NULL,
+  quantity    int         NOT NULL DEFAULT 1,
+  first_seen  timestamptz NOT NULL,
+  last_seen   timestamptz NOT NULL,
+  PRIMARY KEY (region, item_id, enhancement, price, live_at)
+);
+
+-- hot list 관측 기록, 연속으로 보인 구간이 "얼마나 오래 핫했는지"
+CREATE TABLE IF NOT EXISTS hot_list (
+  region          text        NOT NULL,
+  observed_at     timestamptz NOT NULL,
+  item_id         int         NOT NULL,
+  enhancement     smallint    NOT NULL,
+  base_price      bigint,
+  current_stock   bigint,
+  price_direction smallint,
+  price_change    bigint,
+  PRIMARY KEY (region, item_id, enhancement, observed_at)
+);
+CREATE INDEX IF NOT EXISTS hot_list_observed_idx ON hot_list (region, observed_at);
+
+-- hot list 폴링 기록, 빈 응답도 남겨서 "지금은 핫한 게 없음"과 "폴링이 안 됨"을 구분
+CREATE TABLE IF NOT EXISTS hot_list_poll (
+  region    text        NOT NULL,
+  polled_at timestamptz NOT NULL,
+  entries   int         NOT NULL,
+  PRIMARY KEY (region, polled_at)
+);
+
+-- 아이템 그룹(사슴 피 등)별로 사이클마다 고른 가장 싼 대체 아이템
+CREATE TABLE IF NOT EXISTS substitute_choice (
+  region      text        NOT NULL,
+  group_name  text        NOT NULL,
+  chosen_at   timestamptz NOT NULL,
+  item_id     int         NOT NULL,
+  price       bigint,
+  stock       bigint,
+  source      text        NOT NULL,
+  fallback    boolean     NOT NULL DEFAULT false,
+  PRIMARY KEY (region, group_name, chosen_at)
+);
+SELECT public.ensure_bigint('public.substitute_choice', ARRAY['price', 'stock']);
+
+-- 점검/브레이커로 수집을 건너뛴 사이클 (item_ts에 0을 쓰는 대신 기록)
+CREATE TABLE IF NOT EXISTS collect_gaps (
+  region  text        NOT NULL,
+  time    timestamptz NOT NULL,
+  reason  text,
+  PRIMARY KEY (region, time)
+);
+
+-- collector 레플리카 공용 요청 예산 (1초 윈도우)
+CREATE TABLE IF NOT EXISTS api_rate_budget (
+  key          text        PRIMARY KEY,
+  window_start timestamptz NOT NULL,
+  used         int         NOT NULL
+);
+
 ---------------------
 --월 파티션 보장 함수
 CREATE OR REPLACE FUNCTION public.ensure_month_partition(base_table regclass, month_start date)
@@ -101,7 +296,11 @@ BEGIN
   PERFORM public.ensure_month_partition('public.item_ts', this_month - interval '1 month');
   PERFORM public.ensure_month_partition('public.item_ts', this_month);
   PERFORM public.ensure_month_partition('public.item_ts', this_month + interval '1 month');
+  PERFORM public.ensure_month_partition('public.item_enhance_ts', this_month - interval '1 month');
+  PERFORM public.ensure_month_partition('public.item_enhance_ts', this_month);
+  PERFORM public.ensure_month_partition('public.item_enhance_ts', this_month + interval '1 month');
 
   -- 1개월 보관: KST 기준으로 컷오프 이전 파티션 드롭
   PERFORM public.drop_partitions_older_than_by_name('public.item_ts', interval '1 month');
+  PERFORM public.drop_partitions_older_than_by_name('public.item_enhance_ts', interval '1 month');
 END$$;
\ No newline at end of file
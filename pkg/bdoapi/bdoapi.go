@@ -1,21 +1,18 @@
 package bdoapi
 
 import (
-	hfm "bdo_calc_go/pkg/huffmanunpack"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
-	"strconv"
-	"strings"
 	"time"
 )
 
 // 요청 시 Payload 구조체
 type ReqPayload interface {
-	CategoryPayload | MainKeyPayload | MainSubKeyPayload
+	CategoryPayload | MainKeyPayload | MainSubKeyPayload | EmptyPayload | SearchPayload
 }
 type CategoryPayload struct {
 	KeyType      int `json:"keyType"`
@@ -32,356 +29,243 @@ type MainSubKeyPayload struct {
 	SubKey  int `json:"subKey"`
 }
 
+// 검색, searchResult는 아이템 id를 ','로 이은 문자열
+type SearchPayload struct {
+	SearchResult string `json:"searchResult"`
+}
+
+// wait list, hot list 등 키 없는 요청
+type EmptyPayload struct{}
+
 // 응답 시 받는 데이터 구조체
 type RespObject interface {
-	MarketListObject | MarketSubListObject
+	MarketListObject | MarketSubListObject | WaitListObject
 }
+
+// id-재고-총거래량-기준가
 type MarketListObject struct {
-	ItemID       int64
-	CurrentStock int64
-	TotalTrades  int64
-	BasePrice    int64
+	ItemID       int64 `json:"item_id" rec:"0"`
+	CurrentStock int64 `json:"current_stock" rec:"1"`
+	TotalTrades  int64 `json:"total_trades" rec:"2"`
+	BasePrice    int64 `json:"base_price" rec:"3"`
 }
+
+// id-강화최소-강화최대-기준가-재고-총거래량-하드캡최소-하드캡최대-최근거래가-최근거래시각
 type MarketSubListObject struct {
-	ItemID int64
-	// MinEnhance      int8
-	// MaxEnhance      int8
-	// BasePrice       int64
-	CurrentStock int64
-	TotalTrades  int64
-	// MinPriceHardCap int64
-	// MaxPriceHardCap int64
-	LastTradePrice int64
-	// LastTradeTime   time.Time
+	ItemID          int64     `rec:"0"`
+	MinEnhance      int8      `rec:"1"`
+	MaxEnhance      int8      `rec:"2"`
+	BasePrice       int64     `rec:"3"`
+	CurrentStock    int64     `rec:"4"`
+	TotalTrades     int64     `rec:"5"`
+	MinPriceHardCap int64     `rec:"6"`
+	MaxPriceHardCap int64     `rec:"7"`
+	LastTradePrice  int64     `rec:"8"`
+	LastTradeTime   time.Time `rec:"9,unix"` // 지역 시간대, 거래 기록이 없으면 zero
 }
 
-// 내부 연산 시 사용되는 구조체
-type ItemGroup struct {
-	ItemID   int
-	ItemName string
+// 기준가가 하드캡에 걸려 있는지
+func (o MarketSubListObject) AtMaxCap() bool {
+	return o.MaxPriceHardCap > 0 && o.BasePrice >= o.MaxPriceHardCap
+}
+func (o MarketSubListObject) AtMinCap() bool {
+	return o.MinPriceHardCap > 0 && o.BasePrice <= o.MinPriceHardCap
 }
+
+// 내부 연산 시 사용되는 구조체
+// 가격-판매대기-구매대기
 type BiddingOrder struct {
-	Price int64
-	Sale  int64
-	Buy   int64
+	Price int64 `json:"price" rec:"0"`
+	Sale  int64 `json:"sale" rec:"1"`
+	Buy   int64 `json:"buy" rec:"2"`
 }
 
-// 지정값 하드코딩
-var baseUrl = "https://trade.kr.playblackdesert.com/Trademarket/"
-var PayloadMap = map[string]CategoryPayload{
-	"ore":     {KeyType: 0, MainCategory: 25, SubCategory: 1},
-	"plants":  {KeyType: 0, MainCategory: 25, SubCategory: 2},
-	"seed":    {KeyType: 0, MainCategory: 25, SubCategory: 3},
-	"leather": {KeyType: 0, MainCategory: 25, SubCategory: 4},
-	"blood":   {KeyType: 0, MainCategory: 25, SubCategory: 5},
-	"meat":    {KeyType: 0, MainCategory: 25, SubCategory: 6},
-	"seafood": {KeyType: 0, MainCategory: 25, SubCategory: 7},
-	"misc":    {KeyType: 0, MainCategory: 25, SubCategory: 8},
-
-	"offensive_elixir":  {KeyType: 0, MainCategory: 35, SubCategory: 1},
-	"defensive_elixir":  {KeyType: 0, MainCategory: 35, SubCategory: 2},
-	"functional_elixir": {KeyType: 0, MainCategory: 35, SubCategory: 3},
-	"food":              {KeyType: 0, MainCategory: 35, SubCategory: 4},
-	"portion_elixir":    {KeyType: 0, MainCategory: 35, SubCategory: 5},
+var (
+	marketListCodec    = MustRecordCodec[MarketListObject]()
+	marketSubListCodec = MustRecordCodec[MarketSubListObject]()
+	biddingOrderCodec  = MustRecordCodec[BiddingOrder]()
+)
+
+// 아이템 그룹 별 가장 싼 아이템 고르는 용도, 값은 아이템 id (이름은 아이템 카탈로그에서)
+var itemGroupMap = map[string][]int{
+	"deer":   {6201, 6202, 6206, 6215, 6205, 6227, 6228},
+	"wolf":   {6214, 6204, 6216, 6218},
+	"fox":    {6203, 6210, 6211, 6212, 6224, 6226},
+	"bear":   {6213, 6223, 6220, 6221, 6207, 6225},
+	"lizard": {6208, 6209, 6219, 6217, 6222},
+	"meat":   {7913, 7961, 7925, 7901, 7960, 7904, 7911, 7910, 7912, 7905, 7957, 7903, 7906, 7902},
+	"grain":  {7003},
+	"powder": {7103},
+	"dough":  {7203},
 }
 
-// 아이템 그룹 별 가장 싼 아이템 고르는 용도
-var itemGroupMap = map[string][]ItemGroup{
-	"deer": {
-		{ItemID: 6201, ItemName: "사슴 피"},
-		{ItemID: 6202, ItemName: "양 피"},
-		{ItemID: 6206, ItemName: "소 피"},
-		{ItemID: 6215, ItemName: "와라곤 피"},
-		{ItemID: 6205, ItemName: "돼지 피"},
-		{ItemID: 6227, ItemName: "라마 피"},
-		{ItemID: 6228, ItemName: "염소 피"},
-	},
-	"wolf": {
-		{ItemID: 6214, ItemName: "늑대 피"},
-		{ItemID: 6204, ItemName: "코뿔소 피"},
-		{ItemID: 6216, ItemName: "치타룡 피"},
-		{ItemID: 6218, ItemName: "홍학 피"},
-	},
-	"fox": {
-		{ItemID: 6203, ItemName: "여우 피"},
-		{ItemID: 6210, ItemName: "너구리 피"},
-		{ItemID: 6211, ItemName: "원숭이 피"},
-		{ItemID: 6212, ItemName: "족제비 피"},
-		{ItemID: 6224, ItemName: "전갈 피"},
-		{ItemID: 6226, ItemName: "마못 피"},
-	},
-	"bear": {
-		{ItemID: 6213, ItemName: "곰 피"},
-		{ItemID: 6223, ItemName: "사자 피"},
-		{ItemID: 6220, ItemName: "트롤 피"},
-		{ItemID: 6221, ItemName: "오우거 피"},
-		{ItemID: 6207, ItemName: "공룡 피"},
-		{ItemID: 6225, ItemName: "야크 피"},
-	},
-	"lizard": {
-		{ItemID: 6208, ItemName: "도마뱀 피"},
-		{ItemID: 6209, ItemName: "웜 피"},
-		{ItemID: 6219, ItemName: "박쥐 피"},
-		{ItemID: 6217, ItemName: "쿠쿠새 피"},
-		{ItemID: 6222, ItemName: "코브라 피"},
-	},
-
-	"meat": {
-		{ItemID: 7913, ItemName: "늑대 고기"},
-		{ItemID: 7961, ItemName: "토끼 고기"},
-		{ItemID: 7925, ItemName: "가젤 고기"},
-		{ItemID: 7901, ItemName: "사슴 고기"},
-		{ItemID: 7960, ItemName: "강치 고기"},
-		{ItemID: 7904, ItemName: "코뿔소 고기"},
-		{ItemID: 7911, ItemName: "족제비 고기"},
-		{ItemID: 7910, ItemName: "너구리 고기"},
-		{ItemID: 7912, ItemName: "곰고기"},
-		{ItemID: 7905, ItemName: "돼지고기"},
-		{ItemID: 7957, ItemName: "염소 고기"},
-		{ItemID: 7903, ItemName: "여우 고기"},
-		{ItemID: 7906, ItemName: "소고기"},
-		{ItemID: 7902, ItemName: "양고기"},
-	},
-	"grain":  {{ItemID: 7003, ItemName: "감자"}},
-	"powder": {{ItemID: 7103, ItemName: "감자 가루"}},
-	"dough":  {{ItemID: 7203, ItemName: "감자 반죽"}},
+// 그룹 이름 → 멤버 id (첫 번째가 조건에 맞는 아이템이 없을 때 쓰는 기본 아이템)
+// 호출자가 수정해도 원본은 그대로
+func ItemGroups() map[string][]int {
+	out := make(map[string][]int, len(itemGroupMap))
+	for name, members := range itemGroupMap {
+		out[name] = append([]int(nil), members...)
+	}
+	return out
+}
+
+// 요청 후 레코드 문자열 반환, JSON/huffman은 응답을 보고 판단 (decodeBody)
+// WithCache가 있으면 캐시를 거침 (cache.go)
+func doRequest[T ReqPayload](ctx context.Context, c *Client, targetAPI string, payload T) (string, error) {
+	if c.cache != nil {
+		return c.cached(ctx, targetAPI, payload)
+	}
+	return c.fetch(ctx, targetAPI, payload)
 }
 
-// /* 외부로 공개되는 인터페이스 */
-// type BDOAPIController interface {
-// 	SetReq(category string)
-// }
-
-// // 생성 함수
-// func NewBDOAPIController() BDOAPIController {
-// 	return &innerBDOAPIObject{
-// 		BaseUrl:   "https://trade.kr.playblackdesert.com",
-// 		Headers:   "",
-// 		Payloads:  Payload{},
-// 		ItemGroup: "",
-// 	}
-// }
-
-/* object 컨트롤 함수(인터페이스에 적용) */
-// type innerBDOAPIObject struct {
-// 	BaseUrl   string
-// 	Headers   string
-// 	Payloads  Payload
-// 	ItemGroup []int
-// }
-
-func doRequest[T ReqPayload](targetAPI string, payload T) (string, error) {
-	targetUrl := baseUrl + targetAPI
+// 공통 POST 요청, 응답 body 그대로 반환
+// ctx가 취소/만료되면 ctx.Err()를 감싼 에러 반환
+func (c *Client) post(ctx context.Context, targetAPI string, payload any) ([]byte, error) {
 	b, err := json.Marshal(payload)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	if err := c.Available(time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", targetAPI, err)
+	}
+	var gen uint64
+	if c.breaker != nil {
+		if gen, err = c.breaker.Allow(); err != nil {
+			return nil, fmt.Errorf("%s: %w", targetAPI, err)
+		}
 	}
 
-	req, err := http.NewRequest("POST", targetUrl, bytes.NewReader(b))
-	if err != nil {
-		return "", err
+	data, err := withRetry(ctx, c, targetAPI, func() ([]byte, error) {
+		return c.postOnce(ctx, targetAPI, b)
+	})
+	if c.breaker != nil {
+		c.breaker.Record(gen, err)
 	}
+	return data, err
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "BlackDesert")
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+func (c *Client) postOnce(ctx context.Context, targetAPI string, b []byte) ([]byte, error) {
+	resp, err := c.open(ctx, c.httpClient, targetAPI, b)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("%s: %w", targetAPI, ctxErr)
+		}
+		return nil, &TransportError{Endpoint: targetAPI, Err: err}
 	}
-
-	return string(data), nil
+	return data, nil
 }
 
-func doRequestUnpack[T ReqPayload](targetAPI string, payload T) (string, error) {
-	targetUrl := baseUrl + targetAPI
-	b, err := json.Marshal(payload)
-	if err != nil {
-		return "", err
+// 요청 1회, 200이면 body를 열어둔 채로 반환 (닫는 건 호출자)
+func (c *Client) open(ctx context.Context, hc *http.Client, targetAPI string, b []byte) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", targetAPI, err)
 	}
-
-	req, err := http.NewRequest("POST", targetUrl, bytes.NewReader(b))
-	if err != nil {
-		return "", err
+	if err := c.waitLimit(ctx, targetAPI); err != nil {
+		return nil, fmt.Errorf("%s: rate limit: %w", targetAPI, err)
 	}
+	targetUrl := c.baseURL + targetAPI
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "BlackDesert")
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	req, err := http.NewRequestWithContext(ctx, "POST", targetUrl, bytes.NewReader(b))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	resp, err := hc.Do(req)
 	if err != nil {
-		return "", err
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("%s: %w", targetAPI, ctxErr)
+		}
+		return nil, &TransportError{Endpoint: targetAPI, Err: err}
 	}
-
-	unpackedData, err := hfm.UnpackBytes(data)
-	if err != nil {
-		fmt.Println("failed to unpack data:", err)
-		return "", err
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, bodySnippetLen))
+		return nil, newStatusError(targetAPI, resp, data)
 	}
+	return resp, nil
+}
 
-	return unpackedData, nil
+// 레코드 파싱 에러
+func recordErr(endpoint string, idx int, field, raw string, err error) error {
+	return &RecordError{Endpoint: endpoint, Index: idx, Field: field, Raw: raw, Err: err}
 }
 
-// func doParsing[T RespObject](record string, obj T) (T, error) {
-// 	fs := strings.Split(record, "-")
-// }
+/* 패키지 함수: 기본 클라이언트 사용 */
+func GetMarketList(ctx context.Context, category string) ([]MarketListObject, error) {
+	return defaultClient.GetMarketList(ctx, category)
+}
 
-// func (c *innerBDOAPIObject) SetReq(category string) *http.Client {
-func GetMarketList(category string) ([]MarketListObject, error) {
-	marketListRawStr, err := doRequestUnpack("GetWorldMarketList", PayloadMap[category])
-	if err != nil {
-		return nil, fmt.Errorf("wrong request: [GetWorldMarketList] %s", category)
-	}
+func GetMarketSubList(ctx context.Context, mainkey int) ([]MarketSubListObject, error) {
+	return defaultClient.GetMarketSubList(ctx, mainkey)
+}
 
-	parts := strings.Split(marketListRawStr, "|")
-	out := make([]MarketListObject, 0, len(parts))
+func GetBiddingInfoList(ctx context.Context, mainkey int, grade int) (int64, int64, error) {
+	return defaultClient.GetBiddingInfoList(ctx, mainkey, grade)
+}
 
-	for idx, rec := range parts {
-		if rec == "" {
-			continue
-		}
-		fs := strings.SplitN(rec, "-", 4)
-		if len(fs) != 4 {
-			return nil, fmt.Errorf("[%s] id(%d): wrong format... [%s]", category, idx, rec)
-		}
-		itemID, err := strconv.ParseInt(fs[0], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("record %d: ItemID: %w", idx, err)
-		}
-		curr, err := strconv.ParseInt(fs[1], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("record %d: CurrentStock: %w", idx, err)
-		}
-		total, err := strconv.ParseInt(fs[2], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("record %d: TotalTrades: %w", idx, err)
-		}
-		price, err := strconv.ParseInt(fs[3], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("record %d: BasePrice: %w", idx, err)
-		}
-		out = append(out, MarketListObject{
-			ItemID:       itemID,
-			CurrentStock: curr,
-			TotalTrades:  total,
-			BasePrice:    price,
-		})
-	}
-	return out, nil
+func GetOrderBook(ctx context.Context, mainkey int, grade int) (*OrderBook, error) {
+	return defaultClient.GetOrderBook(ctx, mainkey, grade)
 }
 
-// list는 강화단계별로 나뉘어져 있음
-func GetMarketSubList(mainkey int) ([]MarketSubListObject, error) {
-	marketSubListRawStr, err := doRequest("GetWorldMarketSubList", MainKeyPayload{KeyType: 0, MainKey: mainkey})
+// category는 Categories().Lookup 형식 ("ore", "material.ore", "25-1")
+func (c *Client) GetMarketList(ctx context.Context, category string) ([]MarketListObject, error) {
+	cat, err := Categories().Lookup(category)
 	if err != nil {
-		return nil, fmt.Errorf("wrong request: [GetWorldMarketSubList] %d", mainkey)
+		return nil, err
 	}
+	return c.GetMarketListCategory(ctx, cat)
+}
 
-	var respMap map[string]interface{}
-	if err := json.Unmarshal([]byte(marketSubListRawStr), &respMap); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal: [GetWorldMarketSubList] %d", mainkey)
+func (c *Client) GetMarketListCategory(ctx context.Context, cat Category) ([]MarketListObject, error) {
+	marketListRawStr, err := doRequest(ctx, c, "GetWorldMarketList", cat.Payload())
+	if err != nil {
+		return nil, fmt.Errorf("wrong request: [GetWorldMarketList] %s: %w", cat.Path(), err)
 	}
+	return c.parseMarketList("GetWorldMarketList", marketListRawStr)
+}
 
-	resultMsg := respMap["resultMsg"].(string)
-	parts := strings.Split(resultMsg, "|")
-	out := make([]MarketSubListObject, 0, len(parts))
-
-	for idx, rec := range parts {
-		if rec == "" {
-			continue
-		}
-		fs := strings.SplitN(rec, "-", 10)
-		if len(fs) != 10 {
-			return nil, fmt.Errorf("[%d] id(%d): wrong format... [%s]", mainkey, idx, rec)
-		}
-		itemID, err := strconv.ParseInt(fs[0], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("record %d: ItemID: %w", idx, err)
-		}
-		curr, err := strconv.ParseInt(fs[4], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("record %d: CurrentStock: %w", idx, err)
-		}
-		total, err := strconv.ParseInt(fs[5], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("record %d: TotalTrades: %w", idx, err)
-		}
-		price, err := strconv.ParseInt(fs[8], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("record %d: LastTradePrice: %w", idx, err)
-		}
-
-		out = append(out, MarketSubListObject{
-			ItemID:         itemID,
-			CurrentStock:   curr,
-			TotalTrades:    total,
-			LastTradePrice: price,
-		})
-	}
-	return out, nil
+// market list, search list 공통
+func (c *Client) parseMarketList(endpoint string, raw string) ([]MarketListObject, error) {
+	return decodeAll(c, marketListCodec, endpoint, raw)
 }
 
-func GetBiddingInfoList(mainkey int, grade int) (int64, int64, error) {
-	/* 계산기 내부에서 사용 */
-	biddingInfoRawStr, err := doRequestUnpack("GetBiddingInfoList", MainSubKeyPayload{KeyType: 0, MainKey: mainkey, SubKey: grade})
+// list는 강화단계별로 나뉘어져 있음
+func (c *Client) GetMarketSubList(ctx context.Context, mainkey int) ([]MarketSubListObject, error) {
+	marketSubListRawStr, err := doRequest(ctx, c, "GetWorldMarketSubList", MainKeyPayload{KeyType: 0, MainKey: mainkey})
 	if err != nil {
-		return -1, -1, fmt.Errorf("wrong request: [GetBiddingInfoList] %d, %d", mainkey, grade)
+		return nil, fmt.Errorf("wrong request: [GetWorldMarketSubList] %d: %w", mainkey, err)
 	}
-	var orders []BiddingOrder
 
-	// 문자열 파싱
-	parts := strings.Split(biddingInfoRawStr, "|")
-	for _, bid := range parts {
-		if bid == "" {
-			continue
-		}
-		parts := strings.Split(bid, "-")
-		if len(parts) != 3 {
-			continue
-		}
+	// 레코드 하나가 강화 단계 하나 (강화 없는 아이템은 0-0 한 개)
+	return decodeAll(c, marketSubListCodec.In(c.region.Location), "GetWorldMarketSubList", marketSubListRawStr)
+}
 
-		price, err := strconv.ParseInt(parts[0], 10, 64)
-		if err != nil {
-			return -1, -1, fmt.Errorf("record %d: Price: %w", mainkey, err)
-		}
-		sale, err := strconv.ParseInt(parts[1], 10, 64)
-		if err != nil {
-			return -1, -1, fmt.Errorf("record %d: Sale: %w", mainkey, err)
-		}
-		buy, err := strconv.ParseInt(parts[2], 10, 64)
-		if err != nil {
-			return -1, -1, fmt.Errorf("record %d: Buy: %w", mainkey, err)
-		}
-		orders = append(orders, BiddingOrder{Price: price, Sale: sale, Buy: buy})
+// 최저 판매가, 최고 구매가만 필요할 때 (계산기)
+func (c *Client) GetBiddingInfoList(ctx context.Context, mainkey int, grade int) (int64, int64, error) {
+	book, err := c.GetOrderBook(ctx, mainkey, grade)
+	if err != nil {
+		return -1, -1, err
 	}
+	return book.BestAsk(), book.BestBid(), nil
+}
 
-	// 최저 판매가 & 최고 매수가 찾기
-	minSale := int64(math.MaxInt64)
-	maxBuy := int64(0)
-
-	for _, o := range orders {
-		if o.Sale > 0 && o.Price < minSale {
-			minSale = o.Price
-		}
-		if o.Buy > 0 && o.Price > maxBuy {
-			maxBuy = o.Price
-		}
+// 가격대별 판매/구매 대기 전체
+func (c *Client) GetOrderBook(ctx context.Context, mainkey int, grade int) (*OrderBook, error) {
+	biddingInfoRawStr, err := doRequest(ctx, c, "GetBiddingInfoList", MainSubKeyPayload{KeyType: 0, MainKey: mainkey, SubKey: grade})
+	if err != nil {
+		return nil, fmt.Errorf("wrong request: [GetBiddingInfoList] %d, %d: %w", mainkey, grade, err)
 	}
-
-	if minSale == int64(math.MaxInt64) {
-		minSale = 0 // 판매 대기 없음
+	orders, err := decodeAll(c, biddingOrderCodec, "GetBiddingInfoList", biddingInfoRawStr)
+	if err != nil {
+		return nil, err
 	}
-	return minSale, maxBuy, nil
+	return NewOrderBook(int64(mainkey), grade, orders), nil
 }
@@ -0,0 +1,141 @@
+package bdoapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen // cooldown 이후 요청 1개만 시험
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+const (
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = time.Minute
+)
+
+// 연속 실패 threshold번이면 열리고, cooldown 뒤 시험 요청이 성공하면 닫힘
+// 상태가 바뀔 때마다 세대(gen)가 올라감, 요청은 시작할 때의 세대로 결과를 기록
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	state     BreakerState
+	openedAt  time.Time
+	probing   bool
+	gen       uint64
+}
+
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &Breaker{threshold: threshold, cooldown: cooldown}
+}
+
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// 요청 보내도 되는지, 되면 지금 세대를 반환 (결과는 이 값으로 Record)
+func (b *Breaker) Allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return 0, ErrCircuitOpen
+		}
+		b.setState(BreakerHalfOpen)
+		b.probing = true
+	case BreakerHalfOpen:
+		if b.probing {
+			return 0, ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return b.gen, nil
+}
+
+// 요청 결과 반영, 업스트림 장애로 볼 수 없는 에러는 성공 취급
+// 지난 세대에 시작한 요청(ex. 열리기 전에 보낸 요청)은 closed일 때의 실패만 반영
+// (그 성공으로 닫히면 half-open 시험을 건너뜀)
+func (b *Breaker) Record(gen uint64, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	current := gen == b.gen
+	if current && b.state == BreakerHalfOpen {
+		b.probing = false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// 호출자가 끊은 경우는 판단 보류, 시험 요청이었으면 다음 요청이 다시 시험
+		if current && b.state == BreakerHalfOpen {
+			b.setState(BreakerOpen)
+		}
+		return
+	}
+	if err == nil || !countsAsFailure(err) {
+		if current {
+			b.failures = 0
+			if b.state != BreakerClosed {
+				b.setState(BreakerClosed)
+			}
+		}
+		return
+	}
+	switch {
+	case b.state == BreakerHalfOpen && current:
+		b.trip()
+	case b.state == BreakerClosed:
+		if b.failures++; b.failures >= b.threshold {
+			b.trip()
+		}
+	}
+}
+
+func (b *Breaker) setState(s BreakerState) {
+	b.state = s
+	b.gen++
+}
+
+func (b *Breaker) trip() {
+	b.setState(BreakerOpen)
+	b.openedAt = time.Now()
+}
+
+// 네트워크/5xx/점검/응답 깨짐만 카운트 (호출자 취소, 레코드 포맷 오류 등은 제외)
+func countsAsFailure(err error) bool {
+	if errors.Is(err, ErrMaintenance) || IsRetryable(err) {
+		return true
+	}
+	var de *DecodeError
+	return errors.As(err, &de)
+}
+
+func WithBreaker(b *Breaker) Option {
+	return func(c *Client) { c.breaker = b }
+}
@@ -0,0 +1,229 @@
+package bdoapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+)
+
+const testCooldown = 50 * time.Millisecond
+
+// 연속 실패 2번이면 열리는 브레이커 + 재시도 없는 클라이언트
+func newBreakerClient(t *testing.T, m *fakemarket.Market) (*bdoapi.Client, *bdoapi.Breaker) {
+	t.Helper()
+	srv := fakemarket.Start(m)
+	t.Cleanup(srv.Close)
+	b := bdoapi.NewBreaker(2, testCooldown)
+	c := bdoapi.NewClient(
+		bdoapi.WithBaseURL(srv.URL+"/Trademarket/"),
+		bdoapi.WithRetryPolicy(bdoapi.NoRetry),
+		bdoapi.WithRateLimit(0, 1),
+		bdoapi.WithBreaker(b),
+	)
+	return c, b
+}
+
+// sub list 500 두 번 → open → cooldown → half-open
+func openThenHalfOpen(t *testing.T, m *fakemarket.Market, c *bdoapi.Client, b *bdoapi.Breaker) {
+	t.Helper()
+	ctx := context.Background()
+	m.InjectFault("GetWorldMarketSubList", fakemarket.Fault{Status: http.StatusInternalServerError, Times: 2})
+	for range 2 {
+		if _, err := c.GetMarketSubList(ctx, 6201); err == nil {
+			t.Fatal("expected injected failure")
+		}
+	}
+	if got := b.State(); got != bdoapi.BreakerOpen {
+		t.Fatalf("state = %v, want open", got)
+	}
+	if _, err := c.GetMarketSubList(ctx, 6201); !errors.Is(err, bdoapi.ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	time.Sleep(testCooldown + 10*time.Millisecond)
+	if got := b.State(); got != bdoapi.BreakerHalfOpen {
+		t.Fatalf("state = %v, want half-open", got)
+	}
+}
+
+func bloodCategory(t *testing.T) bdoapi.Category {
+	t.Helper()
+	cat, err := bdoapi.Categories().Lookup("material.blood")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cat
+}
+
+// half-open에서 연 스트림이 시험 슬롯을 잡고 있으면 안에서 하는 아이템 요청이 전부 ErrCircuitOpen이 됨
+func TestBreakerHalfOpenStreamWithNestedCalls(t *testing.T) {
+	m := fakemarket.Demo()
+	c, b := newBreakerClient(t, m)
+	openThenHalfOpen(t, m, c, b)
+
+	ctx := context.Background()
+	n := 0
+	for o, err := range c.StreamMarketList(ctx, bloodCategory(t)) {
+		if err != nil {
+			t.Fatalf("stream: %v", err)
+		}
+		if _, err := c.GetMarketSubList(ctx, int(o.ItemID)); err != nil {
+			t.Fatalf("nested sub list %d: %v", o.ItemID, err)
+		}
+		if _, err := c.GetOrderBook(ctx, int(o.ItemID), 0); err != nil {
+			t.Fatalf("nested order book %d: %v", o.ItemID, err)
+		}
+		n++
+	}
+	if n == 0 {
+		t.Fatal("empty stream")
+	}
+	if got := b.State(); got != bdoapi.BreakerClosed {
+		t.Fatalf("state = %v, want closed", got)
+	}
+}
+
+// 스트림이 끝나도 그 안에서 센 실패가 지워지지 않아야 함
+func TestBreakerStreamKeepsNestedFailures(t *testing.T) {
+	m := fakemarket.Demo()
+	c, b := newBreakerClient(t, m)
+	openThenHalfOpen(t, m, c, b)
+
+	ctx := context.Background()
+	first := true
+	for o, err := range c.StreamMarketList(ctx, bloodCategory(t)) {
+		if err != nil {
+			t.Fatalf("stream: %v", err)
+		}
+		if first {
+			// 스트림 헤더 성공으로 닫힌 뒤의 실패 1번
+			m.InjectFault("GetWorldMarketSubList", fakemarket.Fault{Status: http.StatusInternalServerError, Times: 1})
+			if _, err := c.GetMarketSubList(ctx, int(o.ItemID)); err == nil {
+				t.Fatal("expected injected failure")
+			}
+			first = false
+		}
+	}
+	if got := b.State(); got != bdoapi.BreakerClosed {
+		t.Fatalf("state = %v, want closed (1 of 2 failures)", got)
+	}
+	// 남아 있던 실패 1번 + 1번 = threshold
+	m.InjectFault("GetWorldMarketSubList", fakemarket.Fault{Status: http.StatusInternalServerError, Times: 1})
+	c.GetMarketSubList(ctx, 6201)
+	if got := b.State(); got != bdoapi.BreakerOpen {
+		t.Fatalf("state = %v, want open", got)
+	}
+}
+
+// 열리기 전에 보낸 요청이 늦게 성공해도 half-open 시험 없이 닫히면 안 됨
+func TestBreakerIgnoresStaleSuccess(t *testing.T) {
+	m := fakemarket.Demo()
+	srv := fakemarket.Start(m)
+	t.Cleanup(srv.Close)
+	b := bdoapi.NewBreaker(2, time.Minute)
+	c := bdoapi.NewClient(
+		bdoapi.WithBaseURL(srv.URL+"/Trademarket/"),
+		bdoapi.WithRetryPolicy(bdoapi.NoRetry),
+		bdoapi.WithRateLimit(0, 1),
+		bdoapi.WithBreaker(b),
+	)
+	ctx := context.Background()
+
+	m.InjectFault("GetBiddingInfoList", fakemarket.Fault{Latency: 100 * time.Millisecond, Times: 1})
+	slow := make(chan error, 1)
+	go func() {
+		_, err := c.GetOrderBook(ctx, 6201, 0)
+		slow <- err
+	}()
+	for m.Requests("GetBiddingInfoList") == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	m.InjectFault("GetWorldMarketSubList", fakemarket.Fault{Status: http.StatusInternalServerError, Times: 2})
+	for range 2 {
+		c.GetMarketSubList(ctx, 6201)
+	}
+	if err := <-slow; err != nil {
+		t.Fatal(err)
+	}
+	if got := b.State(); got != bdoapi.BreakerOpen {
+		t.Fatalf("state = %v after a stale success, want open", got)
+	}
+	if _, err := c.GetMarketSubList(ctx, 6201); !errors.Is(err, bdoapi.ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestBreakerGenerations(t *testing.T) {
+	boom := &bdoapi.StatusError{StatusCode: http.StatusInternalServerError}
+	allow := func(t *testing.T, b *bdoapi.Breaker) uint64 {
+		t.Helper()
+		gen, err := b.Allow()
+		if err != nil {
+			t.Fatalf("Allow: %v", err)
+		}
+		return gen
+	}
+	trip := func(t *testing.T, b *bdoapi.Breaker) {
+		t.Helper()
+		for range 2 {
+			b.Record(allow(t, b), boom)
+		}
+	}
+
+	t.Run("stale success while half-open keeps the probe", func(t *testing.T) {
+		b := bdoapi.NewBreaker(2, testCooldown)
+		stale := allow(t, b)
+		trip(t, b)
+		time.Sleep(testCooldown + 10*time.Millisecond)
+		probe := allow(t, b)
+		b.Record(stale, nil)
+		if _, err := b.Allow(); !errors.Is(err, bdoapi.ErrCircuitOpen) {
+			t.Fatalf("second probe allowed: %v", err)
+		}
+		b.Record(probe, nil)
+		if got := b.State(); got != bdoapi.BreakerClosed {
+			t.Fatalf("state = %v after probe success, want closed", got)
+		}
+	})
+
+	t.Run("stale failure while half-open is left to the probe", func(t *testing.T) {
+		b := bdoapi.NewBreaker(2, testCooldown)
+		stale := allow(t, b)
+		trip(t, b)
+		time.Sleep(testCooldown + 10*time.Millisecond)
+		probe := allow(t, b)
+		b.Record(stale, boom)
+		b.Record(probe, nil)
+		if got := b.State(); got != bdoapi.BreakerClosed {
+			t.Fatalf("state = %v, want closed", got)
+		}
+	})
+
+	t.Run("stale failure while closed counts", func(t *testing.T) {
+		b := bdoapi.NewBreaker(2, testCooldown)
+		stale := allow(t, b)
+		trip(t, b)
+		time.Sleep(testCooldown + 10*time.Millisecond)
+		b.Record(allow(t, b), nil)
+		b.Record(stale, boom)
+		b.Record(allow(t, b), boom)
+		if got := b.State(); got != bdoapi.BreakerOpen {
+			t.Fatalf("state = %v, want open", got)
+		}
+	})
+
+	t.Run("cancelled probe lets the next request probe", func(t *testing.T) {
+		b := bdoapi.NewBreaker(2, testCooldown)
+		trip(t, b)
+		time.Sleep(testCooldown + 10*time.Millisecond)
+		b.Record(allow(t, b), context.Canceled)
+		b.Record(allow(t, b), nil)
+		if got := b.State(); got != bdoapi.BreakerClosed {
+			t.Fatalf("state = %v, want closed", got)
+		}
+	})
+}
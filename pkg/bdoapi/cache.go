@@ -0,0 +1,286 @@
+package bdoapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+/*
+	응답 캐시 (WithCache)
+	  - 키: 엔드포인트 + 요청 payload (+ 지역/baseURL, 클라이언트 여러 개가 백엔드를 같이 써도 안 섞임)
+	  - 값: 풀어놓은 레코드 문자열 (resultCode 에러, 실패 응답은 저장 안 함)
+	  - TTL 안: 그대로 반환
+	  - TTL ~ TTL+Stale: 오래된 값을 바로 반환하고 백그라운드에서 갱신
+	  - 그 이후/없음: 요청, 같은 키로 동시에 들어온 요청은 하나로 합침 (singleflight)
+	스트리밍(StreamMarketList 등)은 캐시를 거치지 않음
+*/
+
+// 캐시 백엔드, 프로세스 내(MemoryCache) 또는 rediscache
+// ttl은 백엔드가 지울 때까지의 시간 (신선도 판단은 클라이언트가 함)
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// 엔드포인트별 캐시 시간, TTL이 0이면 캐시 안 함
+type CachePolicy struct {
+	TTL   time.Duration
+	Stale time.Duration // TTL이 지난 뒤에도 갱신하는 동안 돌려줄 수 있는 시간
+}
+
+// 호가는 자주 바뀌고, 가격 기록은 하루 단위
+var DefaultCachePolicies = map[string]CachePolicy{
+	"GetBiddingInfoList":       {TTL: 5 * time.Second, Stale: 30 * time.Second},
+	"GetWorldMarketSubList":    {TTL: 30 * time.Second, Stale: 2 * time.Minute},
+	"GetWorldMarketList":       {TTL: 30 * time.Second, Stale: 2 * time.Minute},
+	"GetWorldMarketSearchList": {TTL: 30 * time.Second, Stale: 2 * time.Minute},
+	"GetWorldMarketWaitList":   {TTL: 10 * time.Second, Stale: 30 * time.Second},
+	"GetWorldMarketHotList":    {TTL: time.Minute, Stale: 5 * time.Minute},
+	"GetMarketPriceInfo":       {TTL: 10 * time.Minute, Stale: time.Hour},
+}
+
+// 캐시를 채우는 요청 하나의 제한 시간 (호출자 취소와 분리돼 있으므로 따로 제한)
+const cacheFillTimeout = 30 * time.Second
+
+// 캐시 사용, 정책은 DefaultCachePolicies (WithCachePolicy로 덮어씀)
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+		if c.flight == nil {
+			c.flight = &singleflight.Group{}
+		}
+	}
+}
+
+// 특정 엔드포인트만 다른 캐시 시간 (TTL 0이면 해당 엔드포인트는 캐시 안 함)
+func WithCachePolicy(endpoint string, p CachePolicy) Option {
+	return func(c *Client) {
+		if c.cachePolicy == nil {
+			c.cachePolicy = map[string]CachePolicy{}
+		}
+		c.cachePolicy[endpoint] = p
+	}
+}
+
+func (c *Client) cachePolicyFor(endpoint string) CachePolicy {
+	if p, ok := c.cachePolicy[endpoint]; ok {
+		return p
+	}
+	return DefaultCachePolicies[endpoint]
+}
+
+// 캐시 카운터 (/debug/vars 용)
+type CacheStats struct {
+	Hits       int64 `json:"hits"`
+	StaleHits  int64 `json:"stale_hits"`
+	Misses     int64 `json:"misses"`
+	Coalesced  int64 `json:"coalesced"` // 요청을 보내지 않고 진행 중인 같은 요청의 결과를 받은 수
+	Refreshes  int64 `json:"refreshes"` // 백그라운드 갱신
+	BackendErr int64 `json:"backend_errors"`
+}
+
+type cacheCounters struct {
+	hits, stale, misses, fetches, refreshes, backendErr atomic.Int64
+}
+
+func (c *Client) CacheStats() CacheStats {
+	n := &c.cacheStats
+	return CacheStats{
+		Hits:       n.hits.Load(),
+		StaleHits:  n.stale.Load(),
+		Misses:     n.misses.Load(),
+		Coalesced:  n.misses.Load() - n.fetches.Load(),
+		Refreshes:  n.refreshes.Load(),
+		BackendErr: n.backendErr.Load(),
+	}
+}
+
+// 백엔드에 저장하는 값
+type cacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Raw       string    `json:"raw"`
+}
+
+// ex) bdoapi:kr:https://trade.kr.playblackdesert.com/Trademarket/GetBiddingInfoList:{"keyType":0,...}
+func (c *Client) cacheKey(endpoint string, payload []byte) string {
+	return "bdoapi:" + c.region.Code + ":" + c.baseURL + endpoint + ":" + string(payload)
+}
+
+// 요청 → 레코드 문자열, 캐시를 거치지 않음
+func (c *Client) fetch(ctx context.Context, endpoint string, payload any) (string, error) {
+	data, err := c.post(ctx, endpoint, payload)
+	if err != nil {
+		return "", err
+	}
+	return c.decodeBody(endpoint, data)
+}
+
+func (c *Client) cached(ctx context.Context, endpoint string, payload any) (string, error) {
+	policy := c.cachePolicyFor(endpoint)
+	if policy.TTL <= 0 {
+		return c.fetch(ctx, endpoint, payload)
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	key := c.cacheKey(endpoint, b)
+
+	if entry, ok := c.cacheGet(ctx, key); ok {
+		age := time.Since(entry.FetchedAt)
+		switch {
+		case age < policy.TTL:
+			c.cacheStats.hits.Add(1)
+			return entry.Raw, nil
+		case age < policy.TTL+policy.Stale:
+			c.cacheStats.stale.Add(1)
+			c.refresh(ctx, endpoint, payload, key, policy)
+			return entry.Raw, nil
+		}
+	}
+	c.cacheStats.misses.Add(1)
+
+	// 먼저 온 요청이 취소돼도 기다리던 쪽은 결과를 받도록 요청 자체는 취소와 분리, 대신 시간 제한
+	ch := c.flight.DoChan(key, func() (any, error) {
+		c.cacheStats.fetches.Add(1)
+		fillCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheFillTimeout)
+		defer cancel()
+		return c.fill(fillCtx, endpoint, payload, key, policy)
+	})
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("%s: %w", endpoint, ctx.Err())
+	case res := <-ch:
+		if res.Err != nil {
+			return "", res.Err
+		}
+		return res.Val.(string), nil
+	}
+}
+
+// 요청하고 성공하면 저장
+func (c *Client) fill(ctx context.Context, endpoint string, payload any, key string, policy CachePolicy) (string, error) {
+	raw, err := c.fetch(ctx, endpoint, payload)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(cacheEntry{FetchedAt: time.Now(), Raw: raw})
+	if err == nil {
+		err = c.cache.Set(ctx, key, b, policy.TTL+policy.Stale)
+	}
+	if err != nil {
+		// 저장 실패는 응답에 영향 없음
+		c.cacheStats.backendErr.Add(1)
+	}
+	return raw, nil
+}
+
+// stale 값을 돌려준 뒤 백그라운드 갱신, 같은 키로 이미 진행 중이면 합쳐짐
+func (c *Client) refresh(ctx context.Context, endpoint string, payload any, key string, policy CachePolicy) {
+	c.cacheStats.refreshes.Add(1)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheFillTimeout)
+	ch := c.flight.DoChan(key, func() (any, error) {
+		return c.fill(ctx, endpoint, payload, key, policy)
+	})
+	go func() {
+		defer cancel()
+		<-ch
+	}()
+}
+
+func (c *Client) cacheGet(ctx context.Context, key string) (cacheEntry, bool) {
+	b, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		c.cacheStats.backendErr.Add(1)
+		return cacheEntry{}, false
+	}
+	if !ok {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		c.cacheStats.backendErr.Add(1)
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+/* ---------- 프로세스 내 백엔드 ---------- */
+
+const DefaultMemoryCacheSize = 10000
+
+// 프로세스 내 캐시, 만료된 항목은 가득 찼을 때 정리
+type MemoryCache struct {
+	mu      sync.Mutex
+	max     int
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// maxEntries가 0 이하면 DefaultMemoryCacheSize
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryCacheSize
+	}
+	return &MemoryCache{max: maxEntries, entries: map[string]memoryEntry{}, now: time.Now}
+}
+
+func (m *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !m.now().Before(e.expires) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+func (m *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if _, ok := m.entries[key]; !ok && len(m.entries) >= m.max {
+		m.evict(now)
+	}
+	m.entries[key] = memoryEntry{value: append([]byte(nil), value...), expires: now.Add(ttl)}
+	return nil
+}
+
+// 만료된 것 정리, 그래도 가득 차 있으면 가장 먼저 만료될 것 하나 제거
+func (m *MemoryCache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for k, e := range m.entries {
+		if !now.Before(e.expires) {
+			delete(m.entries, k)
+			continue
+		}
+		if oldestKey == "" || e.expires.Before(oldest) {
+			oldestKey, oldest = k, e.expires
+		}
+	}
+	if len(m.entries) >= m.max && oldestKey != "" {
+		delete(m.entries, oldestKey)
+	}
+}
+
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
@@ -0,0 +1,238 @@
+package bdoapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+)
+
+const (
+	cacheItem = 6201
+	cacheEP   = "GetBiddingInfoList"
+)
+
+func newCacheClient(t *testing.T, m *fakemarket.Market, policy bdoapi.CachePolicy) *bdoapi.Client {
+	t.Helper()
+	srv := fakemarket.Start(m)
+	t.Cleanup(srv.Close)
+	return bdoapi.NewClient(
+		bdoapi.WithBaseURL(srv.URL+"/Trademarket/"),
+		bdoapi.WithRetryPolicy(bdoapi.NoRetry),
+		bdoapi.WithRateLimit(0, 1),
+		bdoapi.WithCache(bdoapi.NewMemoryCache(0)),
+		bdoapi.WithCachePolicy(cacheEP, policy),
+	)
+}
+
+func bestAsk(t *testing.T, c *bdoapi.Client) int64 {
+	t.Helper()
+	book, err := c.GetOrderBook(context.Background(), cacheItem, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return book.BestAsk()
+}
+
+func setAsk(t *testing.T, m *fakemarket.Market, price int64) {
+	t.Helper()
+	if err := m.SetOrders(cacheItem, 0, []bdoapi.BiddingOrder{{Price: price, Sale: 10}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 조건이 맞을 때까지 최대 1초
+func eventually(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in 1s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	m := fakemarket.Demo()
+	setAsk(t, m, 1000)
+	c := newCacheClient(t, m, bdoapi.CachePolicy{TTL: 50 * time.Millisecond})
+
+	bestAsk(t, c)
+	setAsk(t, m, 2000)
+	if got := bestAsk(t, c); got != 1000 {
+		t.Errorf("within TTL: ask %d, want cached 1000", got)
+	}
+	if n := m.Requests(cacheEP); n != 1 {
+		t.Errorf("%d requests within TTL, want 1", n)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if got := bestAsk(t, c); got != 2000 {
+		t.Errorf("after TTL: ask %d, want 2000", got)
+	}
+	if n := m.Requests(cacheEP); n != 2 {
+		t.Errorf("%d requests after TTL, want 2", n)
+	}
+	if st := c.CacheStats(); st.Hits != 1 || st.Misses != 2 || st.StaleHits != 0 {
+		t.Errorf("stats = %+v", st)
+	}
+}
+
+func TestCacheStaleWhileRevalidate(t *testing.T) {
+	m := fakemarket.Demo()
+	setAsk(t, m, 1000)
+	c := newCacheClient(t, m, bdoapi.CachePolicy{TTL: 20 * time.Millisecond, Stale: time.Minute})
+
+	bestAsk(t, c)
+	setAsk(t, m, 2000)
+	m.SetLatency(200 * time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+
+	// 오래된 값은 갱신을 기다리지 않고 바로
+	begin := time.Now()
+	if got := bestAsk(t, c); got != 1000 {
+		t.Errorf("stale: ask %d, want 1000", got)
+	}
+	if elapsed := time.Since(begin); elapsed > 100*time.Millisecond {
+		t.Errorf("stale hit waited %s", elapsed)
+	}
+	eventually(t, func() bool { return m.Requests(cacheEP) == 2 })
+
+	// 갱신이 끝나면 새 값, 그동안 들어온 stale 요청은 갱신 하나로 합쳐짐
+	bestAsk(t, c)
+	m.SetLatency(0)
+	eventually(t, func() bool { return bestAsk(t, c) == 2000 })
+	if n := m.Requests(cacheEP); n != 2 {
+		t.Errorf("%d requests, want 2 (one refresh)", n)
+	}
+	if st := c.CacheStats(); st.StaleHits < 2 || st.Refreshes < 2 || st.Misses != 1 {
+		t.Errorf("stats = %+v", st)
+	}
+}
+
+func TestCacheCoalesce(t *testing.T) {
+	m := fakemarket.Demo()
+	m.SetLatency(100 * time.Millisecond)
+	c := newCacheClient(t, m, bdoapi.CachePolicy{TTL: time.Minute})
+
+	const callers = 10
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = c.GetOrderBook(context.Background(), cacheItem, 0)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := m.Requests(cacheEP); n != 1 {
+		t.Errorf("%d requests, want 1", n)
+	}
+	if st := c.CacheStats(); st.Misses != callers || st.Coalesced != callers-1 {
+		t.Errorf("stats = %+v", st)
+	}
+}
+
+// 먼저 요청한 쪽이 취소돼도 요청은 끝까지 가서 캐시를 채우고 기다리던 쪽은 결과를 받음
+func TestCacheCallerCancel(t *testing.T) {
+	m := fakemarket.Demo()
+	setAsk(t, m, 1000)
+	m.SetLatency(100 * time.Millisecond)
+	c := newCacheClient(t, m, bdoapi.CachePolicy{TTL: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := c.GetOrderBook(ctx, cacheItem, 0)
+		cancelled <- err
+	}()
+	eventually(t, func() bool { return m.Requests(cacheEP) == 1 })
+	waiter, err := c.GetOrderBook(context.Background(), cacheItem, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if waiter.BestAsk() != 1000 {
+		t.Errorf("waiter ask %d, want 1000", waiter.BestAsk())
+	}
+
+	err = <-cancelled
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), cacheEP+": context deadline exceeded") {
+		t.Errorf("err = %v, want %s: context deadline exceeded", err, cacheEP)
+	}
+	m.SetLatency(0)
+	bestAsk(t, c)
+	if n := m.Requests(cacheEP); n != 1 {
+		t.Errorf("%d requests, want 1 (filled despite cancel)", n)
+	}
+}
+
+func TestCacheSkipsErrors(t *testing.T) {
+	m := fakemarket.Demo()
+	c := newCacheClient(t, m, bdoapi.CachePolicy{TTL: time.Minute})
+	m.InjectFault(cacheEP, fakemarket.Fault{Status: http.StatusInternalServerError, Times: 1})
+
+	if _, err := c.GetOrderBook(context.Background(), cacheItem, 0); err == nil {
+		t.Fatal("want error")
+	}
+	bestAsk(t, c)
+	bestAsk(t, c)
+	if n := m.Requests(cacheEP); n != 2 {
+		t.Errorf("%d requests, want 2 (failure not cached)", n)
+	}
+}
+
+func TestMemoryCacheEvict(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		set  []string // "key:ttl"
+		want []string // 남아 있어야 할 키
+		gone []string
+	}{
+		{name: "under limit", set: []string{"a:1h", "b:1h"}, want: []string{"a", "b"}},
+		{name: "drops soonest expiry", set: []string{"a:1h", "b:1m", "c:1h"}, want: []string{"a", "c"}, gone: []string{"b"}},
+		{name: "expired first", set: []string{"a:0s", "b:1m", "c:1h"}, want: []string{"b", "c"}, gone: []string{"a"}},
+		{name: "overwrite keeps others", set: []string{"a:1h", "b:1m", "b:1h"}, want: []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := bdoapi.NewMemoryCache(2)
+			for _, kv := range tt.set {
+				k, ttl, _ := strings.Cut(kv, ":")
+				d, err := time.ParseDuration(ttl)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := mc.Set(ctx, k, []byte(k), d); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if mc.Len() > 2 {
+				t.Errorf("len = %d, want at most 2", mc.Len())
+			}
+			for _, k := range tt.want {
+				if v, ok, _ := mc.Get(ctx, k); !ok || string(v) != k {
+					t.Errorf("%s missing", k)
+				}
+			}
+			for _, k := range tt.gone {
+				if _, ok, _ := mc.Get(ctx, k); ok {
+					t.Errorf("%s not evicted", k)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+// 거래소 요청/응답 녹화·재생 (파서 회귀 테스트용)
+//
+//	rec, _ := cassette.New("testdata/cassettes", cassette.ModeReplay, nil)
+//	client := bdoapi.NewClient(bdoapi.WithTransport(rec))
+//
+// 카세트 하나 = 지역(또는 호스트) + 엔드포인트 + 요청 키(mainKey/subKey/category 등) 하나, JSON 파일 하나
+// 파일은 dir/지역/엔드포인트/키.json, 지역은 요청 호스트로 판단 (WithScope로 지정 가능)
+// 같은 키로 여러 번 녹화하면 순서대로 쌓이고, 재생도 그 순서대로 (다 쓰면 마지막 것 반복)
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi"
+)
+
+// bdoapi 클라이언트가 재시도하지 않도록 Permanent() 구현
+var ErrNoMatch error = noMatchError{}
+
+type noMatchError struct{}
+
+func (noMatchError) Error() string   { return "cassette: no recorded interaction" }
+func (noMatchError) Permanent() bool { return true }
+
+type Mode int
+
+const (
+	ModeReplay Mode = iota // 녹화본만 사용, 없으면 ErrNoMatch
+	ModeRecord             // 항상 실제로 요청하고 녹화
+	ModeAuto               // 녹화본이 있으면 재생, 없으면 녹화
+)
+
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "replay":
+		return ModeReplay, nil
+	case "record":
+		return ModeRecord, nil
+	case "auto":
+		return ModeAuto, nil
+	}
+	return ModeReplay, fmt.Errorf("cassette: unknown mode %q", s)
+}
+
+// 저장하는 응답 헤더 (나머지는 녹화하지 않음)
+var keepHeaders = []string{"Content-Type", "Retry-After"}
+
+// 요청/응답 한 쌍
+type Interaction struct {
+	RecordedAt time.Time         `json:"recorded_at"`
+	Host       string            `json:"host,omitempty"`
+	Request    json.RawMessage   `json:"request"`
+	Status     int               `json:"status"`
+	Header     map[string]string `json:"header,omitempty"`
+	JSON       json.RawMessage   `json:"json,omitempty"` // JSON 응답은 읽을 수 있게 그대로
+	Body       []byte            `json:"body,omitempty"` // 그 외(huffman)는 base64
+}
+
+func (it *Interaction) body() []byte {
+	if len(it.JSON) > 0 {
+		return it.JSON
+	}
+	return it.Body
+}
+
+type Cassette struct {
+	Scope        string         `json:"scope,omitempty"` // 지역 코드 또는 호스트
+	Endpoint     string         `json:"endpoint"`
+	Key          string         `json:"key"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// 파일 읽기
+func Load(file string) (*Cassette, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var c Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("cassette %s: %w", file, err)
+	}
+	return &c, nil
+}
+
+func (c *Cassette) Save(file string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+// http.RoundTripper
+type Recorder struct {
+	dir   string
+	mode  Mode
+	next  http.RoundTripper
+	now   func() time.Time
+	scope string
+
+	mu   sync.Mutex
+	pos  map[string]int // 재생 위치
+	seen map[string]bool
+}
+
+type Option func(*Recorder)
+
+// 호스트와 상관없이 이 이름으로 녹화/재생 (테스트 서버처럼 주소가 매번 바뀔 때, ex. "kr")
+func WithScope(scope string) Option {
+	return func(r *Recorder) { r.scope = scope }
+}
+
+// next가 nil이면 http.DefaultTransport
+func New(dir string, mode Mode, next http.RoundTripper, opts ...Option) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if mode != ModeReplay {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	r := &Recorder{dir: dir, mode: mode, next: next, now: time.Now, pos: map[string]int{}, seen: map[string]bool{}}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
+}
+
+// 카세트 파일 경로, dir/지역/엔드포인트/키.json
+func (r *Recorder) file(scope, endpoint, key string) string {
+	return filepath.Join(r.dir, scope, endpoint, key+".json")
+}
+
+// WithScope > 거래소 호스트의 지역 코드 > 호스트 그대로
+func (r *Recorder) scopeOf(host string) string {
+	if r.scope != "" {
+		return r.scope
+	}
+	return HostScope(host)
+}
+
+// 거래소 호스트면 지역 코드, 아니면 파일 이름으로 쓸 수 있게 바꾼 호스트
+func HostScope(host string) string {
+	for _, reg := range bdoapi.Regions() {
+		if strings.EqualFold(reg.Host, host) {
+			return reg.Code
+		}
+	}
+	if host == "" {
+		return "_"
+	}
+	return strings.NewReplacer(":", "_", "/", "_", "\\", "_").Replace(host)
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var payload []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		payload = b
+	}
+	endpoint := path.Base(req.URL.Path)
+	key, err := MatchKey(payload)
+	if err != nil {
+		return nil, err
+	}
+	scope := r.scopeOf(req.URL.Host)
+	file := r.file(scope, endpoint, key)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode != ModeRecord {
+		c, err := Load(file)
+		switch {
+		case err == nil && len(c.Interactions) > 0:
+			return r.replay(req, file, c), nil
+		case r.mode == ModeReplay:
+			return nil, fmt.Errorf("%w: %s %s %s", ErrNoMatch, scope, endpoint, key)
+		case err != nil && !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		}
+	}
+	return r.record(req, payload, scope, endpoint, key, file)
+}
+
+func (r *Recorder) replay(req *http.Request, file string, c *Cassette) *http.Response {
+	i := r.pos[file]
+	if i >= len(c.Interactions) {
+		i = len(c.Interactions) - 1
+	} else {
+		r.pos[file] = i + 1
+	}
+	it := c.Interactions[i]
+	resp := &http.Response{
+		StatusCode: it.Status,
+		Status:     fmt.Sprintf("%d %s", it.Status, http.StatusText(it.Status)),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader(it.body())),
+		Request:    req,
+	}
+	for k, v := range it.Header {
+		resp.Header.Set(k, v)
+	}
+	resp.ContentLength = int64(len(it.body()))
+	return resp
+}
+
+func (r *Recorder) record(req *http.Request, payload []byte, scope, endpoint, key, file string) (*http.Response, error) {
+	req.Body = io.NopCloser(bytes.NewReader(payload))
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	it := &Interaction{RecordedAt: r.now(), Host: req.URL.Host, Status: resp.StatusCode, Header: map[string]string{}}
+	if json.Valid(payload) {
+		it.Request = payload
+	}
+	for _, h := range keepHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			it.Header[h] = v
+		}
+	}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' && json.Valid(trimmed) {
+		it.JSON = trimmed
+	} else {
+		it.Body = body
+	}
+
+	// 이번 실행에서 처음 녹화하는 키면 기존 녹화본을 덮어씀 (ModeRecord)
+	c := &Cassette{Scope: scope, Endpoint: endpoint, Key: key}
+	if r.seen[file] || r.mode == ModeAuto {
+		if old, err := Load(file); err == nil {
+			c = old
+		}
+	}
+	r.seen[file] = true
+	c.Interactions = append(c.Interactions, it)
+	if err := c.Save(file); err != nil {
+		return nil, fmt.Errorf("cassette: save %s: %w", file, err)
+	}
+	return resp, nil
+}
+
+// 요청 payload → 매칭 키, 0이 아닌 필드를 이름 순으로
+// ex) {"keyType":0,"mainKey":6201,"subKey":0} → "mainKey=6201"
+func MatchKey(payload []byte) (string, error) {
+	if len(bytes.TrimSpace(payload)) == 0 {
+		return "_", nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return "", fmt.Errorf("cassette: request payload: %w", err)
+	}
+	parts := make([]string, 0, len(fields))
+	for k, v := range fields {
+		var s string
+		switch x := v.(type) {
+		case float64:
+			s = strconv.FormatFloat(x, 'f', -1, 64)
+		case string:
+			s = x
+		case nil:
+		default:
+			b, _ := json.Marshal(x)
+			s = string(b)
+		}
+		if s == "" || s == "0" {
+			continue
+		}
+		parts = append(parts, k+"="+s)
+	}
+	if len(parts) == 0 {
+		return "_", nil
+	}
+	sort.Strings(parts)
+	// 파일 이름에 쓸 수 없는 문자 치환
+	key := strings.Join(parts, ",")
+	return strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(key), nil
+}
@@ -0,0 +1,173 @@
+package cassette_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/cassette"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+)
+
+// testdata/cassettes 다시 녹화 (fakemarket 상태 기준, 기존 녹화본에 쌓이므로 지우고 할 것)
+//
+//	rm -r testdata/cassettes && go test . -record && go run ../../../cmd/cassette redact
+var record = flag.Bool("record", false, "re-record testdata cassettes from fakemarket")
+
+const cassetteDir = "testdata/cassettes"
+
+// 녹화 당시 시각, 가격 기록/대기열 시각의 기준
+var recordedAt = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+// 녹화에 쓰는 고정 상태
+func recordedMarket(t *testing.T) *fakemarket.Market {
+	t.Helper()
+	cat, err := bdoapi.Categories().Lookup("material.blood")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := fakemarket.New()
+	m.AddItem(fakemarket.Item{
+		ID: 6201, Name: "사슴 피", MainCategory: cat.MainID, SubCategory: cat.SubID,
+		Levels: []*fakemarket.Level{{
+			BasePrice: 1250, Stock: 8123, TotalTrades: 41_000_000,
+			MinPriceHardCap: 875, MaxPriceHardCap: 1625,
+			LastTradePrice: 1240, LastTradeTime: recordedAt.Add(-3 * time.Minute),
+			Orders:  []bdoapi.BiddingOrder{{Price: 1240, Buy: 300}, {Price: 1250, Sale: 8123}, {Price: 1260, Sale: 50}},
+			History: []int64{1200, 1210, 1230, 1250},
+		}},
+	})
+	m.AddItem(fakemarket.Item{
+		ID: 6202, Name: "양 피", MainCategory: cat.MainID, SubCategory: cat.SubID,
+		Levels: []*fakemarket.Level{{
+			BasePrice: 980, Stock: 0, TotalTrades: 12_500_000,
+			MinPriceHardCap: 686, MaxPriceHardCap: 1274,
+			LastTradePrice: 990, LastTradeTime: recordedAt.Add(-time.Hour),
+		}},
+	})
+	m.SetWaitList([]bdoapi.WaitListObject{{ItemID: 6201, Price: 1300, LiveAt: recordedAt.Add(2 * time.Minute)}})
+	m.SetHot([]fakemarket.HotEntry{{ItemID: 6201, Direction: 1, Change: 40}})
+	return m
+}
+
+// -record면 fakemarket에서 녹화, 아니면 testdata 재생만
+func testClient(t *testing.T, scope string) *bdoapi.Client {
+	t.Helper()
+	mode := cassette.ModeReplay
+	var opts []bdoapi.Option
+	if *record {
+		mode = cassette.ModeRecord
+		srv := fakemarket.Start(recordedMarket(t))
+		t.Cleanup(srv.Close)
+		opts = append(opts, bdoapi.WithBaseURL(srv.URL+"/Trademarket/"))
+	}
+	rec, err := cassette.New(cassetteDir, mode, nil, cassette.WithScope(scope))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bdoapi.NewClient(append(opts,
+		bdoapi.WithTransport(rec),
+		bdoapi.WithRetryPolicy(bdoapi.NoRetry),
+		bdoapi.WithRateLimit(0, 1),
+	)...)
+}
+
+func TestReplayMarketList(t *testing.T) {
+	c := testClient(t, "kr")
+	list, err := c.GetMarketList(context.Background(), "material.blood")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("len = %d, want 2", len(list))
+	}
+	got := list[0]
+	if got.ItemID != 6201 || got.CurrentStock != 8123 || got.TotalTrades != 41_000_000 || got.BasePrice != 1250 {
+		t.Errorf("list[0] = %+v", got)
+	}
+	if list[1].ItemID != 6202 || list[1].CurrentStock != 0 {
+		t.Errorf("list[1] = %+v", list[1])
+	}
+}
+
+func TestReplaySubList(t *testing.T) {
+	c := testClient(t, "kr")
+	subs, err := c.GetMarketSubList(context.Background(), 6201)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 {
+		t.Fatalf("len = %d, want 1", len(subs))
+	}
+	s := subs[0]
+	if s.ItemID != 6201 || s.BasePrice != 1250 || s.MinPriceHardCap != 875 || s.MaxPriceHardCap != 1625 || s.LastTradePrice != 1240 {
+		t.Errorf("sub = %+v", s)
+	}
+}
+
+func TestReplayOrderBook(t *testing.T) {
+	c := testClient(t, "kr")
+	book, err := c.GetOrderBook(context.Background(), 6201, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Levels) != 3 || book.TotalSale != 8173 || book.TotalBuy != 300 {
+		t.Errorf("book = %+v", book)
+	}
+}
+
+func TestReplayPriceHistory(t *testing.T) {
+	c := testClient(t, "kr")
+	hist, err := c.GetMarketPriceInfo(context.Background(), 6201, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hist.Points) != 4 || hist.Points[len(hist.Points)-1].Price != 1250 {
+		t.Errorf("history = %+v", hist)
+	}
+}
+
+func TestReplayWaitAndHotList(t *testing.T) {
+	c := testClient(t, "kr")
+	ctx := context.Background()
+	wait, err := c.GetWaitList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wait) != 1 || wait[0].ItemID != 6201 || wait[0].Price != 1300 || !wait[0].LiveAt.Equal(recordedAt.Add(2*time.Minute)) {
+		t.Errorf("wait list = %+v", wait)
+	}
+	hot, err := c.GetHotList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hot) != 1 || hot[0].ItemID != 6201 || hot[0].PriceDirection != 1 || hot[0].PriceChange != 40 {
+		t.Errorf("hot list = %+v", hot)
+	}
+}
+
+// 다른 지역 녹화본은 재생되지 않음
+func TestReplayOtherScope(t *testing.T) {
+	if *record {
+		t.Skip("recording")
+	}
+	c := testClient(t, "na")
+	if _, err := c.GetMarketSubList(context.Background(), 6201); !errors.Is(err, cassette.ErrNoMatch) {
+		t.Fatalf("err = %v, want ErrNoMatch", err)
+	}
+}
+
+func TestHostScope(t *testing.T) {
+	for _, tt := range []struct{ host, want string }{
+		{"trade.kr.playblackdesert.com", "kr"},
+		{"127.0.0.1:8080", "127.0.0.1_8080"},
+		{"", "_"},
+	} {
+		if got := cassette.HostScope(tt.host); got != tt.want {
+			t.Errorf("HostScope(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
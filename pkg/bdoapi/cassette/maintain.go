@@ -0,0 +1,76 @@
+package cassette
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// 공개 저장소에 올리기 전 지울 것들
+type RedactOptions struct {
+	Host       bool // 녹화한 서버 주소
+	RecordedAt bool // 녹화 시각 (순서만 남김)
+}
+
+// 허용 목록 밖의 헤더는 항상 지움
+func (c *Cassette) Redact(opt RedactOptions) {
+	for _, it := range c.Interactions {
+		if opt.Host {
+			it.Host = ""
+		}
+		if opt.RecordedAt {
+			it.RecordedAt = time.Time{}
+		}
+		for k := range it.Header {
+			if !keptHeader(k) {
+				delete(it.Header, k)
+			}
+		}
+	}
+}
+
+func keptHeader(k string) bool {
+	for _, h := range keepHeaders {
+		if strings.EqualFold(h, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// 오래된 녹화 정리, keep > 0이면 최근 keep개만, maxAge > 0이면 그보다 오래된 것 제거
+// 녹화 시각이 지워진(zero) 것은 maxAge로 지우지 않음, 제거한 개수 반환
+func (c *Cassette) Rotate(keep int, maxAge time.Duration, now time.Time) int {
+	before := len(c.Interactions)
+	if maxAge > 0 {
+		kept := c.Interactions[:0]
+		for _, it := range c.Interactions {
+			if it.RecordedAt.IsZero() || now.Sub(it.RecordedAt) <= maxAge {
+				kept = append(kept, it)
+			}
+		}
+		c.Interactions = kept
+	}
+	if keep > 0 && len(c.Interactions) > keep {
+		c.Interactions = c.Interactions[len(c.Interactions)-keep:]
+	}
+	return before - len(c.Interactions)
+}
+
+// dir 아래 모든 카세트 파일
+func Walk(dir string, fn func(file string, c *Cassette) error) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".json") {
+			return nil
+		}
+		c, err := Load(p)
+		if err != nil {
+			return err
+		}
+		return fn(p, c)
+	})
+}
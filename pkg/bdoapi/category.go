@@ -0,0 +1,200 @@
+package bdoapi
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownCategory = errors.New("unknown category")
+
+//go:embed data/categories.json
+var categoriesJSON []byte
+
+// 서브 카테고리 하나 (market list 요청 단위)
+type Category struct {
+	MainID      int    `json:"main_id"`
+	SubID       int    `json:"sub_id"`
+	MainKey     string `json:"main_key"`
+	Key         string `json:"key"`
+	NameKo      string `json:"name_ko"`
+	NameEn      string `json:"name_en"`
+	Enhanceable bool   `json:"enhanceable"` // 강화 단계(subKey)가 있는 아이템들
+}
+
+// material.ore 형식
+func (c Category) Path() string { return c.MainKey + "." + c.Key }
+
+func (c Category) Payload() CategoryPayload {
+	return CategoryPayload{KeyType: 0, MainCategory: c.MainID, SubCategory: c.SubID}
+}
+
+type MainCategory struct {
+	ID          int        `json:"id"`
+	Key         string     `json:"key"`
+	NameKo      string     `json:"name_ko"`
+	NameEn      string     `json:"name_en"`
+	Enhanceable bool       `json:"enhanceable"`
+	Subs        []Category `json:"subs"`
+}
+
+// 메인/서브 카테고리 트리
+type CategoryTree struct {
+	Mains []MainCategory
+}
+
+// json 파일 형식: [{id, key, name_ko, name_en, enhanceable, subs: [{id, key, name_ko, name_en, enhanceable?}]}]
+func LoadCategories(r io.Reader) (*CategoryTree, error) {
+	var raw []struct {
+		ID          int    `json:"id"`
+		Key         string `json:"key"`
+		NameKo      string `json:"name_ko"`
+		NameEn      string `json:"name_en"`
+		Enhanceable bool   `json:"enhanceable"`
+		Subs        []struct {
+			ID          int    `json:"id"`
+			Key         string `json:"key"`
+			NameKo      string `json:"name_ko"`
+			NameEn      string `json:"name_en"`
+			Enhanceable *bool  `json:"enhanceable"` // 없으면 메인 값 사용
+		} `json:"subs"`
+	}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("load categories: %w", err)
+	}
+
+	t := &CategoryTree{Mains: make([]MainCategory, 0, len(raw))}
+	for _, m := range raw {
+		main := MainCategory{ID: m.ID, Key: m.Key, NameKo: m.NameKo, NameEn: m.NameEn, Enhanceable: m.Enhanceable}
+		for _, s := range m.Subs {
+			enh := m.Enhanceable
+			if s.Enhanceable != nil {
+				enh = *s.Enhanceable
+			}
+			main.Subs = append(main.Subs, Category{
+				MainID:      m.ID,
+				SubID:       s.ID,
+				MainKey:     m.Key,
+				Key:         s.Key,
+				NameKo:      s.NameKo,
+				NameEn:      s.NameEn,
+				Enhanceable: enh,
+			})
+		}
+		t.Mains = append(t.Mains, main)
+	}
+	return t, nil
+}
+
+// 내장 카테고리 (data/categories.json)
+var defaultCategories = func() *CategoryTree {
+	t, err := LoadCategories(bytes.NewReader(categoriesJSON))
+	if err != nil {
+		panic(err)
+	}
+	return t
+}()
+
+func Categories() *CategoryTree { return defaultCategories }
+
+// 전체 서브 카테고리
+func (t *CategoryTree) All() []Category {
+	out := make([]Category, 0)
+	for _, m := range t.Mains {
+		out = append(out, m.Subs...)
+	}
+	return out
+}
+
+func (t *CategoryTree) main(s string) (MainCategory, bool) {
+	id, err := strconv.Atoi(s)
+	for _, m := range t.Mains {
+		if (err == nil && m.ID == id) || strings.EqualFold(m.Key, s) {
+			return m, true
+		}
+	}
+	return MainCategory{}, false
+}
+
+// 서브 카테고리 하나 찾기
+// "25-1", "material.ore", "ore" (서브 key가 유일할 때) 모두 가능
+func (t *CategoryTree) Lookup(s string) (Category, error) {
+	s = strings.TrimSpace(s)
+	if mainPart, subPart, ok := cut2(s); ok {
+		m, ok := t.main(mainPart)
+		if ok {
+			subID, err := strconv.Atoi(subPart)
+			for _, c := range m.Subs {
+				if (err == nil && c.SubID == subID) || strings.EqualFold(c.Key, subPart) {
+					return c, nil
+				}
+			}
+		}
+		return Category{}, fmt.Errorf("%w: %q", ErrUnknownCategory, s)
+	}
+
+	var found []Category
+	for _, c := range t.All() {
+		if strings.EqualFold(c.Key, s) {
+			found = append(found, c)
+		}
+	}
+	switch len(found) {
+	case 1:
+		return found[0], nil
+	case 0:
+		return Category{}, fmt.Errorf("%w: %q", ErrUnknownCategory, s)
+	}
+	return Category{}, fmt.Errorf("%w: %q is ambiguous, use main.sub (ex. %s)", ErrUnknownCategory, s, found[0].Path())
+}
+
+// 이름/ID 목록으로 고르기, 메인 카테고리를 주면 하위 전부, "all"이면 전체
+// ex) Select("material", "35-1", "food")
+func (t *CategoryTree) Select(names ...string) ([]Category, error) {
+	out := make([]Category, 0)
+	seen := map[[2]int]bool{}
+	add := func(cs ...Category) {
+		for _, c := range cs {
+			if k := [2]int{c.MainID, c.SubID}; !seen[k] {
+				seen[k] = true
+				out = append(out, c)
+			}
+		}
+	}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if strings.EqualFold(name, "all") {
+			add(t.All()...)
+			continue
+		}
+		if _, _, ok := cut2(name); !ok {
+			if m, ok := t.main(name); ok {
+				add(m.Subs...)
+				continue
+			}
+		}
+		c, err := t.Lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		add(c)
+	}
+	return out, nil
+}
+
+// "25-1", "25.1", "material.ore" 분리
+func cut2(s string) (string, string, bool) {
+	if a, b, ok := strings.Cut(s, "."); ok {
+		return a, b, true
+	}
+	return strings.Cut(s, "-")
+}
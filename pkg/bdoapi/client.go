@@ -0,0 +1,130 @@
+package bdoapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	DefaultUserAgent = "BlackDesert"
+	DefaultTimeout   = 10 * time.Second
+)
+
+// 거래소 API 클라이언트
+// http.Client를 재사용하므로 여러 고루틴에서 공유해도 됨
+type Client struct {
+	region     Region
+	baseURL    string // WithBaseURL이 없으면 지역 url
+	userAgent  string
+	httpClient *http.Client
+
+	retry         RetryPolicy
+	endpointRetry map[string]RetryPolicy
+
+	limiter         Limiter
+	shared          Limiter
+	endpointLimiter map[string]Limiter
+
+	breaker     *Breaker
+	maintenance MaintenanceCalendar
+
+	parseMode   ParseMode
+	drift       DriftReporter
+	resultCodes map[int]error
+
+	cache       Cache
+	cachePolicy map[string]CachePolicy
+	cacheStats  cacheCounters
+	flight      *singleflight.Group
+}
+
+// 생성 옵션
+type Option func(*Client)
+
+// baseURL 지정 (테스트 서버 등), 끝의 '/'는 자동 보정, 빈 값이면 무시
+func WithBaseURL(u string) Option {
+	return func(c *Client) {
+		if u == "" {
+			return
+		}
+		if !strings.HasSuffix(u, "/") {
+			u += "/"
+		}
+		c.baseURL = u
+	}
+}
+
+// 지역 지정, WithBaseURL이 없으면 해당 지역 url로 요청 (옵션 순서 무관)
+func WithRegion(r Region) Option {
+	return func(c *Client) { c.region = r }
+}
+
+// http.Client 직접 지정 (커넥션 풀 공유용)
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.httpClient = hc
+		}
+	}
+}
+
+// Transport만 교체
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Transport = rt
+		c.httpClient = &hc
+	}
+}
+
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// 요청 전체 타임아웃
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Timeout = d
+		c.httpClient = &hc
+	}
+}
+
+// 생성 함수
+func NewClient(opts ...Option) *Client {
+	region := regions[DefaultRegion]
+	c := &Client{
+		region:      region,
+		userAgent:   DefaultUserAgent,
+		httpClient:  &http.Client{Timeout: DefaultTimeout},
+		retry:       DefaultRetryPolicy,
+		limiter:     NewTokenBucket(DefaultRatePerSecond, DefaultRateBurst),
+		breaker:     NewBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown),
+		maintenance: DefaultMaintenanceCalendar(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.baseURL == "" {
+		c.baseURL = c.region.BaseURL()
+	}
+	return c
+}
+
+func (c *Client) BaseURL() string { return c.baseURL }
+func (c *Client) Region() Region  { return c.region }
+
+// 패키지 함수(GetMarketList 등)가 사용하는 기본 클라이언트
+var defaultClient = NewClient()
+
+func DefaultClient() *Client { return defaultClient }
+
+// 기본 클라이언트 교체 (nil이면 무시)
+func SetDefaultClient(c *Client) {
+	if c != nil {
+		defaultClient = c
+	}
+}
@@ -0,0 +1,270 @@
+package bdoapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+	응답 포맷 변경(게임 패치) 감지
+	  - ParseStrict  : 레코드 하나라도 이상하면 에러 (기본값)
+	  - ParseLenient : 이상한 레코드는 건너뛰고 리포트만
+	어느 모드든 필드 개수 불일치 / 숫자가 아닌 필드는 DriftReporter로 보고
+	빈 응답은 비어 있을 수 없는 엔드포인트(neverEmpty)만 보고
+	(호가/대기/핫/검색은 정상적으로도 비어서 카운터가 의미 없어짐)
+*/
+
+type ParseMode int
+
+const (
+	ParseStrict ParseMode = iota
+	ParseLenient
+)
+
+func (m ParseMode) String() string {
+	if m == ParseLenient {
+		return "lenient"
+	}
+	return "strict"
+}
+
+// "strict", "lenient"
+func LookupParseMode(s string) (ParseMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "strict", "":
+		return ParseStrict, nil
+	case "lenient":
+		return ParseLenient, nil
+	}
+	return ParseStrict, fmt.Errorf("unknown parse mode %q", s)
+}
+
+func WithParseMode(m ParseMode) Option {
+	return func(c *Client) { c.parseMode = m }
+}
+
+func WithDriftReporter(r DriftReporter) Option {
+	return func(c *Client) { c.drift = r }
+}
+
+type DriftKind string
+
+const (
+	DriftFieldCount   DriftKind = "field_count"   // 필드 개수 불일치
+	DriftBadField     DriftKind = "bad_field"     // 숫자가 아닌 필드 등
+	DriftEmptyPayload DriftKind = "empty_payload" // 레코드가 하나도 없음 (neverEmpty 엔드포인트만)
+)
+
+type DriftEvent struct {
+	Endpoint string
+	Kind     DriftKind
+	Field    string // bad_field일 때
+	Fields   int    // 실제 필드 개수
+	Raw      string
+	Err      error
+}
+
+type DriftReporter interface {
+	Report(ev DriftEvent)
+}
+
+// 정상이면 레코드가 하나 이상 있는 엔드포인트
+//   - GetWorldMarketList: 카테고리 목록은 항상 채워져 있음
+//   - GetWorldMarketSubList: 목록에서 받은 id로만 조회하므로 최소 한 단계는 있음
+var neverEmpty = map[string]bool{
+	"GetWorldMarketList":    true,
+	"GetWorldMarketSubList": true,
+}
+
+func (c *Client) reportEmpty(endpoint, raw string) {
+	if neverEmpty[endpoint] {
+		c.reportDrift(DriftEvent{Endpoint: endpoint, Kind: DriftEmptyPayload, Raw: snippet(raw)})
+	}
+}
+
+func (c *Client) reportDrift(ev DriftEvent) {
+	if c.drift != nil {
+		c.drift.Report(ev)
+	}
+}
+
+// 레코드 에러를 리포트하고, lenient면 nil (건너뛰기)
+func (c *Client) recordDrift(rec string, err error) error {
+	var re *RecordError
+	if !errors.As(err, &re) {
+		return err
+	}
+	ev := DriftEvent{Endpoint: re.Endpoint, Kind: DriftBadField, Field: re.Field, Raw: rec, Err: re.Err,
+		Fields: len(strings.Split(rec, FieldSep))}
+	if errors.Is(err, ErrFieldCount) {
+		ev.Kind = DriftFieldCount
+	}
+	c.reportDrift(ev)
+	if c.parseMode == ParseLenient {
+		return nil
+	}
+	return err
+}
+
+// '|'로 구분된 응답 전체를 모드에 맞게 디코딩
+func decodeAll[T any](c *Client, codec *RecordCodec[T], endpoint, raw string) ([]T, error) {
+	parts := strings.Split(raw, RecordSep)
+	out := make([]T, 0, len(parts))
+	for idx, rec := range parts {
+		if rec == "" {
+			continue
+		}
+		v, err := codec.DecodeRecord(endpoint, idx, rec)
+		if err != nil {
+			if err := c.recordDrift(rec, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		out = append(out, v)
+	}
+	if len(out) == 0 {
+		c.reportEmpty(endpoint, raw)
+	}
+	return out, nil
+}
+
+// 스트리밍 버전
+func decodeSeq[T any](c *Client, codec *RecordCodec[T], endpoint string, records iter.Seq2[Record, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		n := 0
+		for rec, err := range records {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			v, err := codec.DecodeRecord(endpoint, rec.Index, rec.Raw)
+			if err != nil {
+				if err := c.recordDrift(rec.Raw, err); err != nil {
+					yield(zero, err)
+					return
+				}
+				continue
+			}
+			n++
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if n == 0 {
+			c.reportEmpty(endpoint, "")
+		}
+	}
+}
+
+func snippet(s string) string {
+	if len(s) > bodySnippetLen {
+		return s[:bodySnippetLen]
+	}
+	return s
+}
+
+/* ---------- 기본 리포터: 엔드포인트별 카운터 + 로그 ---------- */
+
+// 같은 (엔드포인트, 종류) 로그는 이 간격에 한 번만
+const driftLogInterval = time.Minute
+
+type DriftStats struct {
+	FieldCount   int64            `json:"field_count"`
+	BadField     int64            `json:"bad_field"`
+	EmptyPayload int64            `json:"empty_payload"`
+	FieldCounts  map[int]int64    `json:"field_counts,omitempty"` // 개수가 안 맞은 레코드의 실제 필드 수별 건수
+	BadFields    map[string]int64 `json:"bad_fields,omitempty"`   // 필드 이름별 건수
+	LastRaw      string           `json:"last_raw,omitempty"`
+	LastSeen     time.Time        `json:"last_seen"`
+}
+
+// expvar.Publish("bdoapi_drift", m) 하면 /debug/vars로 볼 수 있음
+type DriftMonitor struct {
+	mu     sync.Mutex
+	stats  map[string]*DriftStats
+	logged map[string]time.Time
+	logf   func(format string, args ...any)
+}
+
+// logf가 nil이면 로그 없이 카운트만
+func NewDriftMonitor(logf func(format string, args ...any)) *DriftMonitor {
+	return &DriftMonitor{stats: map[string]*DriftStats{}, logged: map[string]time.Time{}, logf: logf}
+}
+
+func (m *DriftMonitor) Report(ev DriftEvent) {
+	now := time.Now()
+	m.mu.Lock()
+	st, ok := m.stats[ev.Endpoint]
+	if !ok {
+		st = &DriftStats{}
+		m.stats[ev.Endpoint] = st
+	}
+	switch ev.Kind {
+	case DriftFieldCount:
+		st.FieldCount++
+		if st.FieldCounts == nil {
+			st.FieldCounts = map[int]int64{}
+		}
+		st.FieldCounts[ev.Fields]++
+	case DriftBadField:
+		st.BadField++
+		if st.BadFields == nil {
+			st.BadFields = map[string]int64{}
+		}
+		st.BadFields[ev.Field]++
+	case DriftEmptyPayload:
+		st.EmptyPayload++
+	}
+	if ev.Raw != "" {
+		st.LastRaw = ev.Raw
+	}
+	st.LastSeen = now
+
+	key := ev.Endpoint + "/" + string(ev.Kind)
+	shouldLog := m.logf != nil && now.Sub(m.logged[key]) >= driftLogInterval
+	if shouldLog {
+		m.logged[key] = now
+	}
+	m.mu.Unlock()
+
+	if shouldLog {
+		m.logf("schema drift: %s %s field=%q fields=%d err=%v raw=%q",
+			ev.Endpoint, ev.Kind, ev.Field, ev.Fields, ev.Err, ev.Raw)
+	}
+}
+
+// 엔드포인트별 복사본
+func (m *DriftMonitor) Snapshot() map[string]DriftStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[string]DriftStats, len(m.stats))
+	for ep, st := range m.stats {
+		cp := *st
+		cp.FieldCounts = make(map[int]int64, len(st.FieldCounts))
+		for k, v := range st.FieldCounts {
+			cp.FieldCounts[k] = v
+		}
+		cp.BadFields = make(map[string]int64, len(st.BadFields))
+		for k, v := range st.BadFields {
+			cp.BadFields[k] = v
+		}
+		out[ep] = cp
+	}
+	return out
+}
+
+// expvar.Var
+func (m *DriftMonitor) String() string {
+	b, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
@@ -0,0 +1,78 @@
+package bdoapi_test
+
+import (
+	"context"
+	"testing"
+
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+)
+
+func TestEmptyPayloadDrift(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoint  string
+		call      func(c *bdoapi.Client) error
+		wantDrift bool
+	}{
+		{
+			name:     "empty hot list",
+			endpoint: "GetWorldMarketHotList",
+			call:     func(c *bdoapi.Client) error { _, err := c.GetHotList(context.Background()); return err },
+		},
+		{
+			name:     "empty wait list",
+			endpoint: "GetWorldMarketWaitList",
+			call:     func(c *bdoapi.Client) error { _, err := c.GetWaitList(context.Background()); return err },
+		},
+		{
+			name:     "empty market list",
+			endpoint: "GetWorldMarketList",
+			call: func(c *bdoapi.Client) error {
+				_, err := c.GetMarketList(context.Background(), "material.ore")
+				return err
+			},
+			wantDrift: true,
+		},
+		{
+			name:     "empty market list stream",
+			endpoint: "GetWorldMarketList",
+			call: func(c *bdoapi.Client) error {
+				cat, err := bdoapi.Categories().Lookup("material.ore")
+				if err != nil {
+					return err
+				}
+				for _, err := range c.StreamMarketList(context.Background(), cat) {
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantDrift: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := fakemarket.Demo()
+			m.SetHot(nil)
+			m.SetWaitList(nil)
+			srv := fakemarket.Start(m)
+			t.Cleanup(srv.Close)
+			drift := bdoapi.NewDriftMonitor(nil)
+			c := bdoapi.NewClient(
+				bdoapi.WithBaseURL(srv.URL+"/Trademarket/"),
+				bdoapi.WithRetryPolicy(bdoapi.NoRetry),
+				bdoapi.WithRateLimit(0, 1),
+				bdoapi.WithDriftReporter(drift),
+			)
+			if err := tt.call(c); err != nil {
+				t.Fatal(err)
+			}
+			got := drift.Snapshot()[tt.endpoint].EmptyPayload
+			if want := map[bool]int64{false: 0, true: 1}[tt.wantDrift]; got != want {
+				t.Errorf("empty payload drift = %d, want %d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package bdoapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+/*
+	강화 단계별 조회
+	subKey(= 강화 단계)는 0~20, 아이템마다 있는 단계는 sub list 레코드의 MinEnhance~MaxEnhance로 알 수 있음
+	  - 레코드 하나가 한 단계 (장신구 0~5 등)
+	  - 레코드 하나가 구간일 수도 있음 (무기 0~7강은 한 매물로 거래) → 구간 시작(MinEnhance)으로 한 번만 조회
+*/
+
+const MaxSubKey = 20
+
+type EnhanceOptions struct {
+	OrderBooks   bool // GetBiddingInfoList
+	PriceHistory bool // GetMarketPriceInfo
+	Workers      int  // 단계 동시 조회 수, 0이면 DefaultEnhanceWorkers
+}
+
+const DefaultEnhanceWorkers = 4
+
+// 강화 단계 하나 (sub list 레코드 하나)
+type EnhanceLevel struct {
+	Enhance    int                 `json:"enhance"` // subKey, 구간이면 시작 단계
+	MaxEnhance int                 `json:"max_enhance"`
+	Sub        MarketSubListObject `json:"sub"`
+	OrderBook  *OrderBook          `json:"order_book,omitempty"`
+	History    *PriceHistory       `json:"history,omitempty"`
+	Err        error               `json:"-"` // 이 단계의 호가/가격 조회 실패
+}
+
+// sub list → 조회할 단계 목록, 단계 순 (같은 MinEnhance가 또 나오면 처음 것만)
+func EnhanceLevels(subs []MarketSubListObject) []EnhanceLevel {
+	seen := map[int8]bool{}
+	out := make([]EnhanceLevel, 0, len(subs))
+	for _, sub := range subs {
+		if seen[sub.MinEnhance] || sub.MinEnhance < 0 || int(sub.MinEnhance) > MaxSubKey {
+			continue
+		}
+		seen[sub.MinEnhance] = true
+		out = append(out, EnhanceLevel{Enhance: int(sub.MinEnhance), MaxEnhance: int(max(sub.MaxEnhance, sub.MinEnhance)), Sub: sub})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Enhance < out[j].Enhance })
+	return out
+}
+
+func GetEnhanceLevels(ctx context.Context, mainkey int, opts EnhanceOptions) ([]EnhanceLevel, error) {
+	return defaultClient.GetEnhanceLevels(ctx, mainkey, opts)
+}
+
+// sub list를 받아 단계마다 호가/가격 기록 조회
+// sub list 실패만 에러로 반환, 단계별 실패는 EnhanceLevel.Err
+func (c *Client) GetEnhanceLevels(ctx context.Context, mainkey int, opts EnhanceOptions) ([]EnhanceLevel, error) {
+	subs, err := c.GetMarketSubList(ctx, mainkey)
+	if err != nil {
+		return nil, err
+	}
+	levels := EnhanceLevels(subs)
+	return levels, c.FillEnhanceLevels(ctx, mainkey, levels, opts)
+}
+
+// 이미 받은 sub list(EnhanceLevels)에 호가/가격 기록을 채움, ctx가 끝나면 ctx.Err()
+func (c *Client) FillEnhanceLevels(ctx context.Context, mainkey int, levels []EnhanceLevel, opts EnhanceOptions) error {
+	if !opts.OrderBooks && !opts.PriceHistory {
+		return nil
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultEnhanceWorkers
+	}
+	runPool(ctx, workers, len(levels), func(i int) {
+		lv := &levels[i]
+		if opts.OrderBooks {
+			book, err := c.GetOrderBook(ctx, mainkey, lv.Enhance)
+			if err != nil {
+				lv.Err = err
+				return
+			}
+			lv.OrderBook = book
+		}
+		if opts.PriceHistory {
+			hist, err := c.GetMarketPriceInfo(ctx, mainkey, lv.Enhance)
+			if err != nil {
+				lv.Err = err
+				return
+			}
+			lv.History = hist
+		}
+	})
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("enhance levels %d: %w", mainkey, err)
+	}
+	return nil
+}
+
+// 단계별 호가, key는 강화 단계(subKey)
+func (c *Client) GetOrderBooksByEnhance(ctx context.Context, mainkey int) (map[int]*OrderBook, error) {
+	levels, err := c.GetEnhanceLevels(ctx, mainkey, EnhanceOptions{OrderBooks: true})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[int]*OrderBook, len(levels))
+	for _, lv := range levels {
+		if lv.Err != nil {
+			return nil, lv.Err
+		}
+		out[lv.Enhance] = lv.OrderBook
+	}
+	return out, nil
+}
@@ -0,0 +1,159 @@
+package bdoapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+)
+
+const ringID = 11607
+
+// 0~5강 반지, 단계마다 기준가 10억씩 (int32를 넘는 가격 포함)
+func addRing(m *fakemarket.Market) {
+	levels := make([]*fakemarket.Level, 6)
+	for i := range levels {
+		price := int64(i+1) * 1_000_000_000
+		levels[i] = &fakemarket.Level{
+			Enhance:     int8(i),
+			BasePrice:   price,
+			Stock:       int64(10 - i),
+			TotalTrades: int64(100 * (i + 1)),
+			History:     []int64{price - 1, price},
+		}
+	}
+	m.AddItem(fakemarket.Item{ID: ringID, Name: "ring", MainCategory: 20, SubCategory: 1, Levels: levels})
+}
+
+func TestEnhanceLevels(t *testing.T) {
+	sub := func(minEnh, maxEnh int8, price int64) bdoapi.MarketSubListObject {
+		return bdoapi.MarketSubListObject{ItemID: 1, MinEnhance: minEnh, MaxEnhance: maxEnh, BasePrice: price}
+	}
+	tests := []struct {
+		name string
+		subs []bdoapi.MarketSubListObject
+		want [][3]int64 // Enhance, MaxEnhance, BasePrice
+	}{
+		{name: "empty"},
+		{name: "one per level, sorted", subs: []bdoapi.MarketSubListObject{sub(2, 2, 30), sub(0, 0, 10), sub(1, 1, 20)}, want: [][3]int64{{0, 0, 10}, {1, 1, 20}, {2, 2, 30}}},
+		// 무기 0~7강은 한 레코드
+		{name: "range record", subs: []bdoapi.MarketSubListObject{sub(0, 7, 10), sub(8, 8, 80)}, want: [][3]int64{{0, 7, 10}, {8, 8, 80}}},
+		{name: "duplicate keeps first", subs: []bdoapi.MarketSubListObject{sub(1, 1, 20), sub(1, 1, 99)}, want: [][3]int64{{1, 1, 20}}},
+		{name: "out of range dropped", subs: []bdoapi.MarketSubListObject{sub(-1, -1, 1), sub(bdoapi.MaxSubKey, bdoapi.MaxSubKey, 5), sub(bdoapi.MaxSubKey+1, bdoapi.MaxSubKey+1, 6)}, want: [][3]int64{{bdoapi.MaxSubKey, bdoapi.MaxSubKey, 5}}},
+		{name: "max below min", subs: []bdoapi.MarketSubListObject{sub(3, 0, 10)}, want: [][3]int64{{3, 3, 10}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bdoapi.EnhanceLevels(tt.subs)
+			if len(got) != len(tt.want) {
+				t.Fatalf("levels = %+v, want %v", got, tt.want)
+			}
+			for i, lv := range got {
+				if w := tt.want[i]; int64(lv.Enhance) != w[0] || int64(lv.MaxEnhance) != w[1] || lv.Sub.BasePrice != w[2] {
+					t.Errorf("level %d = +%d~%d at %d, want %v", i, lv.Enhance, lv.MaxEnhance, lv.Sub.BasePrice, w)
+				}
+			}
+		})
+	}
+}
+
+func TestGetEnhanceLevels(t *testing.T) {
+	m := fakemarket.New()
+	addRing(m)
+	if err := m.SetOrders(ringID, 3, []bdoapi.BiddingOrder{{Price: 3_900_000_000, Buy: 2}, {Price: 4_100_000_000, Sale: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	c, _ := newBreakerClient(t, m)
+
+	levels, err := c.GetEnhanceLevels(context.Background(), ringID, bdoapi.EnhanceOptions{OrderBooks: true, PriceHistory: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(levels) != 6 {
+		t.Fatalf("%d levels, want 6", len(levels))
+	}
+	for i, lv := range levels {
+		price := int64(i+1) * 1_000_000_000
+		if lv.Err != nil || lv.Enhance != i || lv.Sub.BasePrice != price {
+			t.Fatalf("level %d = %+v", i, lv)
+		}
+		if lv.OrderBook == nil || lv.OrderBook.SubKey != i || lv.History == nil || lv.History.SubKey != i {
+			t.Fatalf("level %d: book %+v history %+v", i, lv.OrderBook, lv.History)
+		}
+		wantAsk, wantBid := price, int64(0)
+		if i == 3 {
+			wantAsk, wantBid = 4_100_000_000, 3_900_000_000
+		}
+		if lv.OrderBook.BestAsk() != wantAsk || lv.OrderBook.BestBid() != wantBid {
+			t.Errorf("+%d ask/bid = %d/%d, want %d/%d", i, lv.OrderBook.BestAsk(), lv.OrderBook.BestBid(), wantAsk, wantBid)
+		}
+		if n := len(lv.History.Points); n != 2 || lv.History.Points[n-1].Price != price {
+			t.Errorf("+%d history = %+v", i, lv.History.Points)
+		}
+	}
+	for ep, want := range map[string]int{"GetWorldMarketSubList": 1, "GetBiddingInfoList": 6, "GetMarketPriceInfo": 6} {
+		if n := m.Requests(ep); n != want {
+			t.Errorf("%s: %d requests, want %d", ep, n, want)
+		}
+	}
+
+	books, err := c.GetOrderBooksByEnhance(context.Background(), ringID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(books) != 6 || books[5].BestAsk() != 6_000_000_000 {
+		t.Errorf("books = %+v", books)
+	}
+}
+
+func TestFillEnhanceLevels(t *testing.T) {
+	m := fakemarket.New()
+	addRing(m)
+	c, _ := newBreakerClient(t, m)
+	ctx := context.Background()
+	subs, err := c.GetMarketSubList(ctx, ringID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("nothing to fill", func(t *testing.T) {
+		levels := bdoapi.EnhanceLevels(subs)
+		if err := c.FillEnhanceLevels(ctx, ringID, levels, bdoapi.EnhanceOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if n := m.Requests("GetBiddingInfoList") + m.Requests("GetMarketPriceInfo"); n != 0 {
+			t.Errorf("%d requests, want 0", n)
+		}
+	})
+
+	// 단계 하나가 실패해도 나머지는 채우고 에러는 그 단계에만
+	t.Run("one level fails", func(t *testing.T) {
+		levels := bdoapi.EnhanceLevels(subs)
+		m.InjectFault("GetBiddingInfoList", fakemarket.Fault{Status: http.StatusBadRequest, Times: 1})
+		if err := c.FillEnhanceLevels(ctx, ringID, levels, bdoapi.EnhanceOptions{OrderBooks: true, Workers: 1}); err != nil {
+			t.Fatal(err)
+		}
+		var se *bdoapi.StatusError
+		if !errors.As(levels[0].Err, &se) || levels[0].OrderBook != nil {
+			t.Errorf("+0 = %+v, want status error", levels[0])
+		}
+		for _, lv := range levels[1:] {
+			if lv.Err != nil || lv.OrderBook == nil {
+				t.Errorf("+%d = %+v", lv.Enhance, lv)
+			}
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		levels := bdoapi.EnhanceLevels(subs)
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		err := c.FillEnhanceLevels(cctx, ringID, levels, bdoapi.EnhanceOptions{OrderBooks: true})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want canceled", err)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package bdoapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	hfm "bdo_calc_go/pkg/huffmanunpack"
+)
+
+/*
+	응답 형식
+	  - JSON     : {"resultCode": 0, "resultMsg": "..."} (sub list, wait list, hot list, price info)
+	  - huffman  : 헤더 첫 4바이트(LE)가 파일 전체 길이, 다음 4바이트는 항상 0 (market list, bidding info, search)
+	엔드포인트마다 형식을 외워두지 않고 body를 보고 판단
+*/
+
+// resultCode가 없는 JSON
+var ErrMissingResult = errors.New("missing resultCode")
+
+// JSON 응답 공통 구조
+type Envelope struct {
+	ResultCode int    `json:"resultCode"`
+	ResultMsg  string `json:"resultMsg"`
+}
+
+// resultCode가 숫자/문자열 어느 쪽으로 와도, resultMsg가 null이어도 받음
+func (e *Envelope) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		ResultCode json.RawMessage `json:"resultCode"`
+		ResultMsg  json.RawMessage `json:"resultMsg"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	code := bytes.Trim(raw.ResultCode, `"`)
+	if len(code) == 0 || string(code) == "null" {
+		return ErrMissingResult
+	}
+	n, err := strconv.Atoi(string(code))
+	if err != nil {
+		return fmt.Errorf("resultCode %s: %w", raw.ResultCode, err)
+	}
+	e.ResultCode = n
+	e.ResultMsg = ""
+	if len(raw.ResultMsg) > 0 && string(raw.ResultMsg) != "null" {
+		if err := json.Unmarshal(raw.ResultMsg, &e.ResultMsg); err != nil {
+			// 문자열이 아니면 원문 그대로
+			e.ResultMsg = string(raw.ResultMsg)
+		}
+	}
+	return nil
+}
+
+// resultCode → 에러 종류, 관측된 코드를 WithResultCodes로 추가
+// (메시지로 알 수 있는 점검은 코드와 상관없이 ErrMaintenance)
+func WithResultCodes(codes map[int]error) Option {
+	return func(c *Client) {
+		if c.resultCodes == nil {
+			c.resultCodes = map[int]error{}
+		}
+		for code, err := range codes {
+			c.resultCodes[code] = err
+		}
+	}
+}
+
+// resultCode != 0 이면 *ResultCodeError
+func (c *Client) checkEnvelope(endpoint string, env Envelope) error {
+	if env.ResultCode == 0 {
+		return nil
+	}
+	return &ResultCodeError{Endpoint: endpoint, Code: env.ResultCode, Msg: env.ResultMsg, Kind: c.resultCodes[env.ResultCode]}
+}
+
+// huffman 헤더의 두 번째 4바이트는 항상 0이라 JSON과 겹치지 않음 (앞 8바이트만 보면 됨)
+func looksJSON(head []byte) bool {
+	if len(head) >= 8 && binary.LittleEndian.Uint32(head[4:8]) == 0 {
+		return false
+	}
+	trimmed := bytes.TrimSpace(head)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+// body → 레코드 문자열 (JSON이면 resultMsg, 아니면 huffman을 푼 결과)
+func (c *Client) decodeBody(endpoint string, data []byte) (string, error) {
+	if !looksJSON(data) {
+		s, err := hfm.UnpackBytes(data)
+		if err != nil {
+			return "", &DecodeError{Endpoint: endpoint, Format: "huffman", Err: err}
+		}
+		return s, nil
+	}
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return "", &DecodeError{Endpoint: endpoint, Format: "json", Err: err}
+	}
+	if err := c.checkEnvelope(endpoint, env); err != nil {
+		return "", err
+	}
+	return env.ResultMsg, nil
+}
@@ -0,0 +1,144 @@
+package bdoapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+	에러 분류
+	  - TransportError  : 연결/타임아웃 등 네트워크 실패 → 재시도 가능
+	  - StatusError     : 200이 아닌 응답 → 5xx/429는 재시도 가능
+	  - DecodeError     : huffman unpack/json 디코딩 실패 → 해당 아이템 스킵
+	  - RecordError     : 레코드 포맷 불일치 → 해당 아이템 스킵 (패치로 포맷 변경 의심)
+	  - ResultCodeError : resultCode != 0 응답 (WithResultCodes로 코드별 에러 종류 지정)
+	  - ErrMaintenance  : 점검 중 → 사이클 중단
+*/
+
+var (
+	ErrMaintenance = errors.New("trade market under maintenance")
+	// 레코드의 필드 개수가 맞지 않음
+	ErrFieldCount = errors.New("unexpected field count")
+)
+
+// 응답 body 스니펫 최대 길이
+const bodySnippetLen = 256
+
+type TransportError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("%s: transport: %v", e.Endpoint, e.Err)
+}
+func (e *TransportError) Unwrap() error { return e.Err }
+
+type StatusError struct {
+	Endpoint   string
+	StatusCode int
+	Body       string        // 앞부분만
+	RetryAfter time.Duration // Retry-After 헤더가 있을 때
+}
+
+func newStatusError(endpoint string, resp *http.Response, body []byte) *StatusError {
+	snippet := string(body)
+	if len(snippet) > bodySnippetLen {
+		snippet = snippet[:bodySnippetLen]
+	}
+	return &StatusError{
+		Endpoint:   endpoint,
+		StatusCode: resp.StatusCode,
+		Body:       snippet,
+		RetryAfter: parseRetryAfter(resp.Header),
+	}
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: http %d: %q", e.Endpoint, e.StatusCode, e.Body)
+}
+
+// 503은 점검으로 취급
+func (e *StatusError) Is(target error) bool {
+	return target == ErrMaintenance && e.StatusCode == http.StatusServiceUnavailable
+}
+
+type DecodeError struct {
+	Endpoint string
+	Format   string // huffman, json
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s: decode %s: %v", e.Endpoint, e.Format, e.Err)
+}
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+type RecordError struct {
+	Endpoint string
+	Index    int
+	Field    string // 필드 개수 오류면 빈 값
+	Raw      string
+	Err      error
+}
+
+func (e *RecordError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: record %d: %v [%s]", e.Endpoint, e.Index, e.Err, e.Raw)
+	}
+	return fmt.Sprintf("%s: record %d: %s: %v [%s]", e.Endpoint, e.Index, e.Field, e.Err, e.Raw)
+}
+func (e *RecordError) Unwrap() error { return e.Err }
+
+type ResultCodeError struct {
+	Endpoint string
+	Code     int
+	Msg      string
+	Kind     error // 코드에 매핑된 에러 (없으면 nil)
+}
+
+func (e *ResultCodeError) Error() string {
+	return fmt.Sprintf("%s: resultCode %d: %s", e.Endpoint, e.Code, e.Msg)
+}
+
+// 매핑된 종류, 또는 점검 안내 메시지로 내려오는 경우
+func (e *ResultCodeError) Is(target error) bool {
+	if e.Kind != nil && errors.Is(e.Kind, target) {
+		return true
+	}
+	if target != ErrMaintenance {
+		return false
+	}
+	msg := strings.ToLower(e.Msg)
+	return strings.Contains(msg, "maintenance") || strings.Contains(msg, "점검")
+}
+
+// Transport(RoundTripper)가 다시 해도 같은 결과라고 알려주는 에러 (녹화본 없음 등)
+type permanent interface {
+	Permanent() bool
+}
+
+// 재시도해볼 만한 에러인지 (호출자 ctx 취소/점검은 제외)
+// http.Client 자체 타임아웃은 TransportError로 오므로 재시도 대상
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, ErrMaintenance) {
+		return false
+	}
+	var te *TransportError
+	if errors.As(err, &te) {
+		var p permanent
+		return !errors.As(te.Err, &p) || !p.Permanent()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.StatusCode == http.StatusTooManyRequests || se.StatusCode >= 500
+	}
+	return false
+}
@@ -0,0 +1,249 @@
+package fakemarket_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+)
+
+// 재시도는 바로바로 (대기 1ms)
+var fastRetry = bdoapi.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+func start(t *testing.T, m *fakemarket.Market, opts ...bdoapi.Option) *bdoapi.Client {
+	t.Helper()
+	srv := fakemarket.Start(m)
+	t.Cleanup(srv.Close)
+	return bdoapi.NewClient(append([]bdoapi.Option{
+		bdoapi.WithBaseURL(srv.URL + "/Trademarket/"),
+		bdoapi.WithRetryPolicy(fastRetry),
+		bdoapi.WithRateLimit(0, 1),
+	}, opts...)...)
+}
+
+func TestClientAgainstDemo(t *testing.T) {
+	m := fakemarket.Demo()
+	c := start(t, m)
+	ctx := context.Background()
+
+	want, ok := m.Item(6201)
+	if !ok {
+		t.Fatal("demo has no 6201")
+	}
+	lv := want.Levels[0]
+
+	list, err := c.GetMarketList(ctx, "material.blood")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, o := range list {
+		if o.ItemID == 6201 {
+			found = true
+			if o.CurrentStock != lv.Stock || o.TotalTrades != lv.TotalTrades || o.BasePrice != lv.BasePrice {
+				t.Errorf("list 6201 = %+v, want stock %d trades %d price %d", o, lv.Stock, lv.TotalTrades, lv.BasePrice)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("6201 not in material.blood list (%d items)", len(list))
+	}
+
+	subs, err := c.GetMarketSubList(ctx, 6201)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 || subs[0].BasePrice != lv.BasePrice || subs[0].MinPriceHardCap != lv.MinPriceHardCap {
+		t.Errorf("sub list = %+v", subs)
+	}
+
+	// 호가를 안 넣은 단계는 기준가에 재고만큼 판매 대기
+	book, err := c.GetOrderBook(ctx, 6201, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.BestAsk() != lv.BasePrice || book.TotalSale != lv.Stock || book.TotalBuy != 0 {
+		t.Errorf("order book = %+v", book)
+	}
+
+	hist, err := c.GetMarketPriceInfo(ctx, 6201, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hist.Points) != len(lv.History) {
+		t.Errorf("history points = %d, want %d", len(hist.Points), len(lv.History))
+	}
+
+	found = false
+	search, err := c.SearchMarket(ctx, 6201, 6202)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range search {
+		found = found || o.ItemID == 6201
+	}
+	if !found {
+		t.Errorf("search = %+v", search)
+	}
+}
+
+func TestMarketChangesAreVisible(t *testing.T) {
+	m := fakemarket.Demo()
+	c := start(t, m)
+	ctx := context.Background()
+
+	if err := m.SetStock(6201, 0, 42); err != nil {
+		t.Fatal(err)
+	}
+	orders := []bdoapi.BiddingOrder{{Price: 900, Buy: 10}, {Price: 1100, Sale: 5}}
+	if err := m.SetOrders(6201, 0, orders); err != nil {
+		t.Fatal(err)
+	}
+	subs, err := c.GetMarketSubList(ctx, 6201)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subs[0].CurrentStock != 42 {
+		t.Errorf("stock = %d, want 42", subs[0].CurrentStock)
+	}
+	book, err := c.GetOrderBook(ctx, 6201, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.BestBid() != 900 || book.BestAsk() != 1100 {
+		t.Errorf("bid/ask = %d/%d, want 900/1100", book.BestBid(), book.BestAsk())
+	}
+
+	live := time.Now().Add(time.Minute).Truncate(time.Second)
+	m.SetWaitList([]bdoapi.WaitListObject{{ItemID: 6201, Price: 1500, LiveAt: live}})
+	wait, err := c.GetWaitList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wait) != 1 || wait[0].Price != 1500 || !wait[0].LiveAt.Equal(live) {
+		t.Errorf("wait list = %+v", wait)
+	}
+}
+
+// JSON/압축 어느 쪽으로 내려줘도 같은 결과
+func TestFormats(t *testing.T) {
+	m := fakemarket.Demo()
+	c := start(t, m)
+	ctx := context.Background()
+
+	packed, err := c.GetMarketList(ctx, "material.blood")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetFormat("GetWorldMarketList", fakemarket.FormatJSON)
+	plain, err := c.GetMarketList(ctx, "material.blood")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packed) == 0 || len(packed) != len(plain) {
+		t.Fatalf("packed %d records, json %d", len(packed), len(plain))
+	}
+	for i := range packed {
+		if packed[i] != plain[i] {
+			t.Errorf("record %d: packed %+v, json %+v", i, packed[i], plain[i])
+		}
+	}
+}
+
+func TestFaults(t *testing.T) {
+	const ep = "GetWorldMarketSubList"
+	tests := []struct {
+		name     string
+		fault    fakemarket.Fault
+		check    func(error) bool
+		requests int
+	}{
+		{
+			name:     "transient 500 is retried",
+			fault:    fakemarket.Fault{Status: http.StatusInternalServerError, Times: 1},
+			check:    func(err error) bool { return err == nil },
+			requests: 2,
+		},
+		{
+			name:  "persistent 500 gives up",
+			fault: fakemarket.Fault{Status: http.StatusInternalServerError},
+			check: func(err error) bool {
+				var se *bdoapi.StatusError
+				return errors.As(err, &se) && se.StatusCode == http.StatusInternalServerError
+			},
+			requests: 3,
+		},
+		{
+			name:     "503 is maintenance",
+			fault:    fakemarket.Fault{Status: http.StatusServiceUnavailable},
+			check:    func(err error) bool { return errors.Is(err, bdoapi.ErrMaintenance) },
+			requests: 1,
+		},
+		{
+			name:  "429 carries retry-after",
+			fault: fakemarket.Fault{Status: http.StatusTooManyRequests, RetryAfter: 7 * time.Second},
+			check: func(err error) bool {
+				var se *bdoapi.StatusError
+				return errors.As(err, &se) && se.RetryAfter == 7*time.Second
+			},
+			requests: 1,
+		},
+		{
+			name:  "result code",
+			fault: fakemarket.Fault{ResultCode: 9, ResultMsg: "bad request"},
+			check: func(err error) bool {
+				var re *bdoapi.ResultCodeError
+				return errors.As(err, &re) && re.Code == 9
+			},
+			requests: 1,
+		},
+		{
+			name:  "garbage body",
+			fault: fakemarket.Fault{Garbage: true},
+			check: func(err error) bool {
+				var de *bdoapi.DecodeError
+				return errors.As(err, &de)
+			},
+			requests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := fakemarket.Demo()
+			c := start(t, m)
+			m.InjectFault(ep, tt.fault)
+			// 429 재시도는 Retry-After만큼 기다리므로 한 번만
+			if tt.fault.RetryAfter > 0 {
+				c = start(t, m, bdoapi.WithRetryPolicy(bdoapi.NoRetry))
+			}
+			_, err := c.GetMarketSubList(context.Background(), 6201)
+			if !tt.check(err) {
+				t.Errorf("err = %v (%T)", err, err)
+			}
+			if got := m.Requests(ep); got != tt.requests {
+				t.Errorf("requests = %d, want %d", got, tt.requests)
+			}
+		})
+	}
+}
+
+func TestLatencyRespectsDeadline(t *testing.T) {
+	m := fakemarket.Demo()
+	c := start(t, m, bdoapi.WithRetryPolicy(bdoapi.NoRetry))
+	m.SetLatency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	begin := time.Now()
+	_, err := c.GetMarketSubList(ctx, 6201)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(begin); elapsed > 500*time.Millisecond {
+		t.Errorf("returned after %s", elapsed)
+	}
+}
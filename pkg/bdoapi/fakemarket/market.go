@@ -0,0 +1,396 @@
+// 오프라인 개발/테스트용 가짜 거래소
+//
+//	m := fakemarket.Demo()
+//	srv := fakemarket.Start(m)
+//	defer srv.Close()
+//	client := bdoapi.NewClient(bdoapi.WithBaseURL(srv.URL + "/Trademarket/"))
+package fakemarket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi"
+)
+
+var ErrNoItem = errors.New("fakemarket: no such item")
+
+// 강화 단계 하나 (sub list 레코드 하나)
+type Level struct {
+	Enhance         int8                  `json:"enhance"`
+	BasePrice       int64                 `json:"base_price"`
+	Stock           int64                 `json:"stock"`
+	TotalTrades     int64                 `json:"total_trades"`
+	MinPriceHardCap int64                 `json:"min_price_hard_cap"`
+	MaxPriceHardCap int64                 `json:"max_price_hard_cap"`
+	LastTradePrice  int64                 `json:"last_trade_price"`
+	LastTradeTime   time.Time             `json:"last_trade_time"`
+	Orders          []bdoapi.BiddingOrder `json:"orders,omitempty"`  // 비어 있으면 기준가에 재고만큼 판매 대기
+	History         []int64               `json:"history,omitempty"` // 일별 가격, 마지막이 오늘
+}
+
+type Item struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	MainCategory int      `json:"main_category"`
+	SubCategory  int      `json:"sub_category"`
+	Levels       []*Level `json:"levels"`
+}
+
+// hot list에 올릴 항목
+type HotEntry struct {
+	ItemID    int64 `json:"item_id"`
+	Enhance   int8  `json:"enhance"`
+	Direction int8  `json:"direction"` // 1: 상승, 2: 하락
+	Change    int64 `json:"change"`
+}
+
+// JSON으로 저장/불러오는 전체 상태
+type State struct {
+	Items    []*Item                 `json:"items"`
+	WaitList []bdoapi.WaitListObject `json:"wait_list,omitempty"`
+	Hot      []HotEntry              `json:"hot,omitempty"`
+}
+
+// 응답 body 형식
+type Format int
+
+const (
+	FormatDefault Format = iota // 실제 거래소와 같게
+	FormatJSON
+	FormatPacked
+)
+
+// 엔드포인트에 끼워 넣는 장애
+type Fault struct {
+	Latency    time.Duration
+	Status     int // 200이 아닌 상태코드로 응답
+	RetryAfter time.Duration
+	ResultCode int // JSON envelope의 resultCode로 에러
+	ResultMsg  string
+	Garbage    bool // 풀 수 없는 body
+	Times      int  // 몇 번 적용할지, 0이면 계속
+}
+
+// 모든 엔드포인트에 적용되는 Fault 키
+const AllEndpoints = "*"
+
+type Market struct {
+	mu       sync.Mutex
+	items    map[int64]*Item
+	waitList []bdoapi.WaitListObject
+	hot      []HotEntry
+	faults   map[string][]*Fault
+	formats  map[string]Format
+	latency  time.Duration
+	requests map[string]int
+}
+
+func New() *Market {
+	return &Market{
+		items:    map[int64]*Item{},
+		faults:   map[string][]*Fault{},
+		formats:  map[string]Format{},
+		requests: map[string]int{},
+	}
+}
+
+// State JSON 불러오기
+func Load(r io.Reader) (*Market, error) {
+	var st State
+	if err := json.NewDecoder(r).Decode(&st); err != nil {
+		return nil, fmt.Errorf("fakemarket: state: %w", err)
+	}
+	m := New()
+	for _, it := range st.Items {
+		m.AddItem(*it)
+	}
+	m.waitList = st.WaitList
+	m.hot = st.Hot
+	return m, nil
+}
+
+// 현재 상태 복사본 (저장용)
+func (m *Market) State() State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st := State{WaitList: append([]bdoapi.WaitListObject(nil), m.waitList...), Hot: append([]HotEntry(nil), m.hot...)}
+	for _, it := range m.sortedItems() {
+		st.Items = append(st.Items, cloneItem(it))
+	}
+	return st
+}
+
+// 같은 id가 있으면 교체, 단계가 없으면 0강 하나 추가
+func (m *Market) AddItem(it Item) {
+	cp := cloneItem(&it)
+	if len(cp.Levels) == 0 {
+		cp.Levels = []*Level{{}}
+	}
+	m.mu.Lock()
+	m.items[cp.ID] = cp
+	m.mu.Unlock()
+}
+
+func (m *Market) Item(id int64) (Item, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	it, ok := m.items[id]
+	if !ok {
+		return Item{}, false
+	}
+	return *cloneItem(it), true
+}
+
+func cloneItem(it *Item) *Item {
+	cp := *it
+	cp.Levels = make([]*Level, len(it.Levels))
+	for i, lv := range it.Levels {
+		l := *lv
+		l.Orders = append([]bdoapi.BiddingOrder(nil), lv.Orders...)
+		l.History = append([]int64(nil), lv.History...)
+		cp.Levels[i] = &l
+	}
+	return &cp
+}
+
+// id 순
+func (m *Market) sortedItems() []*Item {
+	out := make([]*Item, 0, len(m.items))
+	for _, it := range m.items {
+		out = append(out, it)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// 호출하는 쪽에서 잠금
+func (m *Market) level(id int64, enhance int8) (*Level, error) {
+	it, ok := m.items[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrNoItem, id)
+	}
+	for _, lv := range it.Levels {
+		if lv.Enhance == enhance {
+			return lv, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d +%d", ErrNoItem, id, enhance)
+}
+
+func (m *Market) SetStock(id int64, enhance int8, stock int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lv, err := m.level(id, enhance)
+	if err != nil {
+		return err
+	}
+	lv.Stock = stock
+	return nil
+}
+
+// 기준가 변경 (하드캡 안으로), 오늘 가격 기록도 갱신
+func (m *Market) SetPrice(id int64, enhance int8, price int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lv, err := m.level(id, enhance)
+	if err != nil {
+		return err
+	}
+	if lv.MaxPriceHardCap > 0 && price > lv.MaxPriceHardCap {
+		price = lv.MaxPriceHardCap
+	}
+	if lv.MinPriceHardCap > 0 && price < lv.MinPriceHardCap {
+		price = lv.MinPriceHardCap
+	}
+	lv.BasePrice = price
+	if n := len(lv.History); n > 0 {
+		lv.History[n-1] = price
+	}
+	return nil
+}
+
+// qty개가 price에 팔림
+func (m *Market) Trade(id int64, enhance int8, qty, price int64, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lv, err := m.level(id, enhance)
+	if err != nil {
+		return err
+	}
+	lv.Stock = max(lv.Stock-qty, 0)
+	lv.TotalTrades += qty
+	lv.LastTradePrice = price
+	lv.LastTradeTime = at
+	return nil
+}
+
+func (m *Market) SetOrders(id int64, enhance int8, orders []bdoapi.BiddingOrder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lv, err := m.level(id, enhance)
+	if err != nil {
+		return err
+	}
+	lv.Orders = append([]bdoapi.BiddingOrder(nil), orders...)
+	return nil
+}
+
+func (m *Market) SetWaitList(list []bdoapi.WaitListObject) {
+	m.mu.Lock()
+	m.waitList = append([]bdoapi.WaitListObject(nil), list...)
+	m.mu.Unlock()
+}
+
+func (m *Market) SetHot(entries []HotEntry) {
+	m.mu.Lock()
+	m.hot = append([]HotEntry(nil), entries...)
+	m.mu.Unlock()
+}
+
+// 모든 응답에 붙는 지연
+func (m *Market) SetLatency(d time.Duration) {
+	m.mu.Lock()
+	m.latency = d
+	m.mu.Unlock()
+}
+
+// endpoint는 "GetWorldMarketList" 등, AllEndpoints면 전부
+func (m *Market) InjectFault(endpoint string, f Fault) {
+	m.mu.Lock()
+	m.faults[endpoint] = append(m.faults[endpoint], &f)
+	m.mu.Unlock()
+}
+
+func (m *Market) ClearFaults() {
+	m.mu.Lock()
+	m.faults = map[string][]*Fault{}
+	m.mu.Unlock()
+}
+
+func (m *Market) SetFormat(endpoint string, f Format) {
+	m.mu.Lock()
+	m.formats[endpoint] = f
+	m.mu.Unlock()
+}
+
+// 엔드포인트별 받은 요청 수
+func (m *Market) Requests(endpoint string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.requests[endpoint]
+}
+
+// 요청 수 기록하고 적용할 장애 하나 꺼냄 (Times 소진된 건 제거)
+func (m *Market) take(endpoint string) (*Fault, time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[endpoint]++
+	for _, key := range []string{endpoint, AllEndpoints} {
+		fs := m.faults[key]
+		if len(fs) == 0 {
+			continue
+		}
+		f := *fs[0]
+		if fs[0].Times > 0 {
+			fs[0].Times--
+			if fs[0].Times == 0 {
+				m.faults[key] = fs[1:]
+			}
+		}
+		return &f, m.latency
+	}
+	return nil, m.latency
+}
+
+// 무작위 거래/가격 변동 한 번 (cmd/fakemarket의 -tick)
+func (m *Market) Step(rng *rand.Rand, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, it := range m.sortedItems() {
+		for _, lv := range it.Levels {
+			if lv.Stock > 0 && rng.Intn(3) == 0 {
+				qty := rng.Int63n(lv.Stock/10+1) + 1
+				lv.Stock = max(lv.Stock-qty, 0)
+				lv.TotalTrades += qty
+				lv.LastTradePrice = lv.BasePrice
+				lv.LastTradeTime = now
+			}
+			if rng.Intn(5) == 0 {
+				lv.Stock += rng.Int63n(1000)
+			}
+			if rng.Intn(10) == 0 {
+				delta := lv.BasePrice / 50 // ±2%
+				if rng.Intn(2) == 0 {
+					delta = -delta
+				}
+				p := lv.BasePrice + delta
+				if lv.MaxPriceHardCap > 0 {
+					p = min(p, lv.MaxPriceHardCap)
+				}
+				if lv.MinPriceHardCap > 0 {
+					p = max(p, lv.MinPriceHardCap)
+				}
+				lv.BasePrice = p
+				if n := len(lv.History); n > 0 {
+					lv.History[n-1] = p
+				}
+			}
+		}
+	}
+}
+
+// item group(피, 고기 등)으로 채운 데모 상태
+func Demo() *Market {
+	m := New()
+	cats := bdoapi.Categories()
+	groupCat := map[string]string{
+		"deer": "material.blood", "wolf": "material.blood", "fox": "material.blood",
+		"bear": "material.blood", "lizard": "material.blood",
+		"meat": "material.meat", "grain": "material.plants", "powder": "material.misc", "dough": "material.misc",
+	}
+	groups := bdoapi.ItemGroups()
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	for gi, name := range names {
+		cat, err := cats.Lookup(groupCat[name])
+		if err != nil {
+			continue
+		}
+		for i, id := range groups[name] {
+			price := int64(1000 + 150*i + 37*gi)
+			history := make([]int64, 90)
+			for d := range history {
+				history[d] = price - int64((89-d)%7)*10
+			}
+			m.AddItem(Item{
+				ID:           int64(id),
+				Name:         fmt.Sprintf("%s %d", name, i+1),
+				MainCategory: cat.MainID,
+				SubCategory:  cat.SubID,
+				Levels: []*Level{{
+					BasePrice:       price,
+					Stock:           int64(5000 + 4000*((i+gi)%5)),
+					TotalTrades:     int64(1_000_000 * (i + 1)),
+					MinPriceHardCap: price * 7 / 10,
+					MaxPriceHardCap: price * 13 / 10,
+					LastTradePrice:  price,
+					LastTradeTime:   now.Add(-time.Duration(i) * time.Minute),
+					History:         history,
+				}},
+			})
+		}
+	}
+	return m
+}
@@ -0,0 +1,248 @@
+package fakemarket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi"
+	hfm "bdo_calc_go/pkg/huffmanunpack"
+)
+
+var (
+	marketListCodec    = bdoapi.MustRecordCodec[bdoapi.MarketListObject]()
+	marketSubListCodec = bdoapi.MustRecordCodec[bdoapi.MarketSubListObject]()
+	biddingOrderCodec  = bdoapi.MustRecordCodec[bdoapi.BiddingOrder]()
+	waitListCodec      = bdoapi.MustRecordCodec[bdoapi.WaitListObject]()
+	hotListCodec       = bdoapi.MustRecordCodec[bdoapi.HotListObject]()
+)
+
+// 실제 거래소의 응답 형식
+var defaultFormats = map[string]Format{
+	"GetWorldMarketList":       FormatPacked,
+	"GetWorldMarketSearchList": FormatPacked,
+	"GetBiddingInfoList":       FormatPacked,
+	"GetWorldMarketSubList":    FormatJSON,
+	"GetMarketPriceInfo":       FormatJSON,
+	"GetWorldMarketWaitList":   FormatJSON,
+	"GetWorldMarketHotList":    FormatJSON,
+}
+
+// 요청 payload 전부 (엔드포인트마다 쓰는 필드만 다름)
+type request struct {
+	KeyType      int    `json:"keyType"`
+	MainCategory int    `json:"mainCategory"`
+	SubCategory  int    `json:"subCategory"`
+	MainKey      int    `json:"mainKey"`
+	SubKey       int    `json:"subKey"`
+	SearchResult string `json:"searchResult"`
+}
+
+type server struct {
+	m *Market
+}
+
+// POST /Trademarket/{endpoint}
+func NewServer(m *Market) http.Handler {
+	return &server{m: m}
+}
+
+// httptest 서버로 띄움, baseURL은 srv.URL + "/Trademarket/"
+func Start(m *Market) *httptest.Server {
+	return httptest.NewServer(NewServer(m))
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	endpoint := strings.TrimPrefix(r.URL.Path, "/Trademarket/")
+	if r.Method != http.MethodPost || endpoint == r.URL.Path {
+		http.NotFound(w, r)
+		return
+	}
+	if _, ok := defaultFormats[endpoint]; !ok {
+		http.NotFound(w, r)
+		return
+	}
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fault, latency := s.m.take(endpoint)
+	if fault != nil {
+		latency += fault.Latency
+	}
+	if latency > 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(latency):
+		}
+	}
+
+	if fault != nil {
+		switch {
+		case fault.Status != 0 && fault.Status != http.StatusOK:
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+			}
+			http.Error(w, http.StatusText(fault.Status), fault.Status)
+			return
+		case fault.Garbage:
+			w.Write([]byte("\x00garbage"))
+			return
+		case fault.ResultCode != 0:
+			writeJSON(w, fault.ResultCode, fault.ResultMsg)
+			return
+		}
+	}
+
+	body := s.records(endpoint, req)
+	if s.format(endpoint) == FormatPacked {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(hfm.Pack(body))
+		return
+	}
+	writeJSON(w, 0, body)
+}
+
+func (s *server) format(endpoint string) Format {
+	s.m.mu.Lock()
+	f := s.m.formats[endpoint]
+	s.m.mu.Unlock()
+	if f == FormatDefault {
+		return defaultFormats[endpoint]
+	}
+	return f
+}
+
+func writeJSON(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bdoapi.Envelope{ResultCode: code, ResultMsg: msg})
+}
+
+// 엔드포인트별 레코드 문자열
+func (s *server) records(endpoint string, req request) string {
+	m := s.m
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch endpoint {
+	case "GetWorldMarketList":
+		var out []bdoapi.MarketListObject
+		for _, it := range m.sortedItems() {
+			if it.MainCategory == req.MainCategory && it.SubCategory == req.SubCategory {
+				out = append(out, listObject(it))
+			}
+		}
+		return marketListCodec.Encode(out)
+
+	case "GetWorldMarketSearchList":
+		var out []bdoapi.MarketListObject
+		for _, f := range strings.Split(req.SearchResult, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(f), 10, 64)
+			if err != nil {
+				continue
+			}
+			if it, ok := m.items[id]; ok {
+				out = append(out, listObject(it))
+			}
+		}
+		return marketListCodec.Encode(out)
+
+	case "GetWorldMarketSubList":
+		it, ok := m.items[int64(req.MainKey)]
+		if !ok {
+			return ""
+		}
+		out := make([]bdoapi.MarketSubListObject, 0, len(it.Levels))
+		for _, lv := range it.Levels {
+			out = append(out, bdoapi.MarketSubListObject{
+				ItemID:          it.ID,
+				MinEnhance:      lv.Enhance,
+				MaxEnhance:      lv.Enhance,
+				BasePrice:       lv.BasePrice,
+				CurrentStock:    lv.Stock,
+				TotalTrades:     lv.TotalTrades,
+				MinPriceHardCap: lv.MinPriceHardCap,
+				MaxPriceHardCap: lv.MaxPriceHardCap,
+				LastTradePrice:  lv.LastTradePrice,
+				LastTradeTime:   lv.LastTradeTime,
+			})
+		}
+		return marketSubListCodec.Encode(out)
+
+	case "GetBiddingInfoList":
+		lv, err := m.level(int64(req.MainKey), int8(req.SubKey))
+		if err != nil {
+			return ""
+		}
+		orders := lv.Orders
+		if len(orders) == 0 {
+			orders = []bdoapi.BiddingOrder{{Price: lv.BasePrice, Sale: lv.Stock}}
+		}
+		return biddingOrderCodec.Encode(orders)
+
+	case "GetMarketPriceInfo":
+		lv, err := m.level(int64(req.MainKey), int8(req.SubKey))
+		if err != nil {
+			return ""
+		}
+		history := lv.History
+		if len(history) == 0 {
+			history = []int64{lv.BasePrice}
+		}
+		fs := make([]string, len(history))
+		for i, p := range history {
+			fs[i] = strconv.FormatInt(p, 10)
+		}
+		return strings.Join(fs, bdoapi.FieldSep)
+
+	case "GetWorldMarketWaitList":
+		if len(m.waitList) == 0 {
+			return "0"
+		}
+		return waitListCodec.Encode(m.waitList)
+
+	case "GetWorldMarketHotList":
+		out := make([]bdoapi.HotListObject, 0, len(m.hot))
+		for _, h := range m.hot {
+			lv, err := m.level(h.ItemID, h.Enhance)
+			if err != nil {
+				continue
+			}
+			out = append(out, bdoapi.HotListObject{
+				ItemID:          h.ItemID,
+				MinEnhance:      lv.Enhance,
+				MaxEnhance:      lv.Enhance,
+				BasePrice:       lv.BasePrice,
+				CurrentStock:    lv.Stock,
+				TotalTrades:     lv.TotalTrades,
+				PriceDirection:  h.Direction,
+				PriceChange:     h.Change,
+				MinPriceHardCap: lv.MinPriceHardCap,
+				MaxPriceHardCap: lv.MaxPriceHardCap,
+				LastTradePrice:  lv.LastTradePrice,
+				LastTradeTime:   lv.LastTradeTime,
+			})
+		}
+		return hotListCodec.Encode(out)
+	}
+	return ""
+}
+
+// market list 레코드는 0강 기준, 재고/거래량은 단계 합계
+func listObject(it *Item) bdoapi.MarketListObject {
+	o := bdoapi.MarketListObject{ItemID: it.ID}
+	for i, lv := range it.Levels {
+		if i == 0 {
+			o.BasePrice = lv.BasePrice
+		}
+		o.CurrentStock += lv.Stock
+		o.TotalTrades += lv.TotalTrades
+	}
+	return o
+}
@@ -0,0 +1,39 @@
+package bdoapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// 거래소 메인의 "급등/급락" 목록
+// id-강화최소-강화최대-기준가-재고-총거래량-등락방향-등락폭-하드캡최소-하드캡최대-최근거래가-최근거래시각
+type HotListObject struct {
+	ItemID          int64     `json:"item_id" rec:"0"`
+	MinEnhance      int8      `json:"min_enhance" rec:"1"`
+	MaxEnhance      int8      `json:"max_enhance" rec:"2"`
+	BasePrice       int64     `json:"base_price" rec:"3"`
+	CurrentStock    int64     `json:"current_stock" rec:"4"`
+	TotalTrades     int64     `json:"total_trades" rec:"5"`
+	PriceDirection  int8      `json:"price_direction" rec:"6"` // 1: 상승, 2: 하락
+	PriceChange     int64     `json:"price_change" rec:"7"`
+	MinPriceHardCap int64     `json:"min_price_hard_cap" rec:"8"`
+	MaxPriceHardCap int64     `json:"max_price_hard_cap" rec:"9"`
+	LastTradePrice  int64     `json:"last_trade_price" rec:"10"`
+	LastTradeTime   time.Time `json:"last_trade_time" rec:"11,unix"`
+}
+
+var hotListCodec = MustRecordCodec[HotListObject]()
+
+func GetHotList(ctx context.Context) ([]HotListObject, error) {
+	return defaultClient.GetHotList(ctx)
+}
+
+func (c *Client) GetHotList(ctx context.Context) ([]HotListObject, error) {
+	resultMsg, err := doRequest(ctx, c, "GetWorldMarketHotList", EmptyPayload{})
+	if err != nil {
+		return nil, fmt.Errorf("wrong request: [GetWorldMarketHotList]: %w", err)
+	}
+
+	return decodeAll(c, hotListCodec.In(c.region.Location), "GetWorldMarketHotList", resultMsg)
+}
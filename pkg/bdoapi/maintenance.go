@@ -0,0 +1,128 @@
+package bdoapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 주간 점검 시간대 (지역 로컬 시간 기준)
+type MaintenanceWindow struct {
+	Weekday time.Weekday
+	Start   time.Duration // 자정부터
+	End     time.Duration // Start보다 작으면 다음날로 넘어감
+}
+
+func (w MaintenanceWindow) contains(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	// 전날 시작해서 자정을 넘긴 경우도 확인
+	for _, day := range []time.Time{midnight, midnight.AddDate(0, 0, -1)} {
+		if day.Weekday() != w.Weekday {
+			continue
+		}
+		start := day.Add(w.Start)
+		end := day.Add(w.End)
+		if w.End <= w.Start {
+			end = end.AddDate(0, 0, 1)
+		}
+		if !t.Before(start) && t.Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w MaintenanceWindow) String() string {
+	return fmt.Sprintf("%s %s-%s", strings.ToLower(w.Weekday.String()[:3]), fmtClock(w.Start), fmtClock(w.End))
+}
+
+func fmtClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// "thu 00:00-08:00" 형식
+func ParseMaintenanceWindow(s string) (MaintenanceWindow, error) {
+	var day, span string
+	if _, err := fmt.Sscan(s, &day, &span); err != nil {
+		return MaintenanceWindow{}, fmt.Errorf("maintenance window %q: %w", s, err)
+	}
+	wd, ok := weekdays[strings.ToLower(day)[:min(3, len(day))]]
+	if !ok {
+		return MaintenanceWindow{}, fmt.Errorf("maintenance window %q: bad weekday", s)
+	}
+	from, to, ok := strings.Cut(span, "-")
+	if !ok {
+		return MaintenanceWindow{}, fmt.Errorf("maintenance window %q: want HH:MM-HH:MM", s)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return MaintenanceWindow{}, fmt.Errorf("maintenance window %q: %w", s, err)
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return MaintenanceWindow{}, fmt.Errorf("maintenance window %q: %w", s, err)
+	}
+	return MaintenanceWindow{Weekday: wd, Start: start, End: end}, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// 지역 코드별 점검 시간표
+type MaintenanceCalendar map[string][]MaintenanceWindow
+
+// 지역 로컬 시간으로 변환해서 비교
+func (cal MaintenanceCalendar) InMaintenance(r Region, t time.Time) bool {
+	if r.Location != nil {
+		t = t.In(r.Location)
+	}
+	for _, w := range cal[r.Code] {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// 지역별 기본값 (대략적인 정기점검 시간, 연장 점검은 브레이커가 잡음)
+func DefaultMaintenanceCalendar() MaintenanceCalendar {
+	thu := func(from, to time.Duration) []MaintenanceWindow {
+		return []MaintenanceWindow{{Weekday: time.Thursday, Start: from, End: to}}
+	}
+	return MaintenanceCalendar{
+		"kr":   thu(0, 8*time.Hour),
+		"jp":   thu(0, 8*time.Hour),
+		"tw":   thu(0, 8*time.Hour),
+		"sea":  thu(0, 8*time.Hour),
+		"na":   thu(0, 3*time.Hour),
+		"eu":   thu(7*time.Hour, 10*time.Hour),
+		"sa":   thu(0, 4*time.Hour),
+		"mena": thu(4*time.Hour, 8*time.Hour),
+	}
+}
+
+func WithMaintenanceCalendar(cal MaintenanceCalendar) Option {
+	return func(c *Client) { c.maintenance = cal }
+}
+
+// 지금 요청해도 되는지: 점검 시간표 → 브레이커 순
+// collector가 사이클 시작 전에 확인하고, 에러면 사이클을 건너뜀
+func (c *Client) Available(now time.Time) error {
+	if c.maintenance.InMaintenance(c.region, now) {
+		return fmt.Errorf("%s: scheduled: %w", c.region.Code, ErrMaintenance)
+	}
+	if c.breaker != nil && c.breaker.State() == BreakerOpen {
+		return fmt.Errorf("%s: %w", c.region.Code, ErrCircuitOpen)
+	}
+	return nil
+}
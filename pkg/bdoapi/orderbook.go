@@ -0,0 +1,65 @@
+package bdoapi
+
+import "sort"
+
+// 가격대 하나 + 누적 수량
+type OrderBookLevel struct {
+	BiddingOrder
+	CumSale int64 `json:"cum_sale"` // 이 가격 이하 판매대기 합 (이 가격까지 올려 사면 살 수 있는 수량)
+	CumBuy  int64 `json:"cum_buy"`  // 이 가격 이상 구매대기 합 (이 가격까지 내려 팔면 팔 수 있는 수량)
+}
+
+// GetBiddingInfoList 응답 전체
+type OrderBook struct {
+	ItemID    int64            `json:"item_id"`
+	SubKey    int              `json:"sub_key"`
+	Levels    []OrderBookLevel `json:"levels"`     // 가격 오름차순
+	TotalSale int64            `json:"total_sale"` // 총 판매대기
+	TotalBuy  int64            `json:"total_buy"`  // 총 구매대기
+}
+
+func NewOrderBook(itemID int64, subKey int, orders []BiddingOrder) *OrderBook {
+	sorted := make([]BiddingOrder, len(orders))
+	copy(sorted, orders)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Price < sorted[j].Price })
+
+	book := &OrderBook{ItemID: itemID, SubKey: subKey, Levels: make([]OrderBookLevel, len(sorted))}
+	for i, o := range sorted {
+		book.TotalSale += o.Sale
+		book.Levels[i] = OrderBookLevel{BiddingOrder: o, CumSale: book.TotalSale}
+	}
+	for i := len(book.Levels) - 1; i >= 0; i-- {
+		book.TotalBuy += book.Levels[i].Buy
+		book.Levels[i].CumBuy = book.TotalBuy
+	}
+	return book
+}
+
+// 최저 판매가 (내가 살 때 가격), 판매대기 없으면 0
+func (b *OrderBook) BestAsk() int64 {
+	for _, l := range b.Levels {
+		if l.Sale > 0 {
+			return l.Price
+		}
+	}
+	return 0
+}
+
+// 최고 구매가 (내가 팔 때 가격), 구매대기 없으면 0
+func (b *OrderBook) BestBid() int64 {
+	for i := len(b.Levels) - 1; i >= 0; i-- {
+		if b.Levels[i].Buy > 0 {
+			return b.Levels[i].Price
+		}
+	}
+	return 0
+}
+
+// 한쪽이라도 비어 있으면 0
+func (b *OrderBook) Spread() int64 {
+	ask, bid := b.BestAsk(), b.BestBid()
+	if ask == 0 || bid == 0 {
+		return 0
+	}
+	return ask - bid
+}
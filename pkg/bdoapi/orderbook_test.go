@@ -0,0 +1,102 @@
+package bdoapi_test
+
+import (
+	"testing"
+
+	"bdo_calc_go/pkg/bdoapi"
+)
+
+func TestNewOrderBook(t *testing.T) {
+	tests := []struct {
+		name           string
+		orders         []bdoapi.BiddingOrder
+		want           []bdoapi.OrderBookLevel
+		ask, bid, sprd int64
+	}{
+		{name: "empty"},
+		{
+			// 입력 순서와 상관없이 가격 오름차순, 누적은 판매는 아래부터 / 구매는 위부터
+			name: "both sides unsorted",
+			orders: []bdoapi.BiddingOrder{
+				{Price: 1100, Sale: 5},
+				{Price: 900, Buy: 7},
+				{Price: 1000, Buy: 2, Sale: 3},
+				{Price: 800, Buy: 4},
+			},
+			want: []bdoapi.OrderBookLevel{
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 800, Buy: 4}, CumSale: 0, CumBuy: 13},
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 900, Buy: 7}, CumSale: 0, CumBuy: 9},
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 1000, Buy: 2, Sale: 3}, CumSale: 3, CumBuy: 2},
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 1100, Sale: 5}, CumSale: 8, CumBuy: 0},
+			},
+			ask: 1000, bid: 1000, sprd: 0,
+		},
+		{
+			name:   "sell only",
+			orders: []bdoapi.BiddingOrder{{Price: 2000, Sale: 1}, {Price: 1500, Sale: 2}},
+			want: []bdoapi.OrderBookLevel{
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 1500, Sale: 2}, CumSale: 2},
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 2000, Sale: 1}, CumSale: 3},
+			},
+			ask: 1500,
+		},
+		{
+			name:   "buy only",
+			orders: []bdoapi.BiddingOrder{{Price: 500, Buy: 1}, {Price: 700, Buy: 2}},
+			want: []bdoapi.OrderBookLevel{
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 500, Buy: 1}, CumBuy: 3},
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 700, Buy: 2}, CumBuy: 2},
+			},
+			bid: 700,
+		},
+		{
+			// 수량 0인 가격대는 최저/최고가에서 빠짐
+			name:   "empty levels skipped",
+			orders: []bdoapi.BiddingOrder{{Price: 100}, {Price: 200, Buy: 1}, {Price: 300, Sale: 1}, {Price: 400}},
+			want: []bdoapi.OrderBookLevel{
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 100}, CumBuy: 1},
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 200, Buy: 1}, CumBuy: 1},
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 300, Sale: 1}, CumSale: 1},
+				{BiddingOrder: bdoapi.BiddingOrder{Price: 400}, CumSale: 1},
+			},
+			ask: 300, bid: 200, sprd: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := append([]bdoapi.BiddingOrder(nil), tt.orders...)
+			book := bdoapi.NewOrderBook(6201, 3, tt.orders)
+			for i := range in {
+				if tt.orders[i] != in[i] {
+					t.Fatal("input orders modified")
+				}
+			}
+			if book.ItemID != 6201 || book.SubKey != 3 {
+				t.Errorf("book id/sub key = %d/%d", book.ItemID, book.SubKey)
+			}
+			if len(book.Levels) != len(tt.want) {
+				t.Fatalf("levels = %+v, want %+v", book.Levels, tt.want)
+			}
+			var sale, buy int64
+			for i, l := range book.Levels {
+				if l != tt.want[i] {
+					t.Errorf("level %d = %+v, want %+v", i, l, tt.want[i])
+				}
+				sale += l.Sale
+				buy += l.Buy
+			}
+			if book.TotalSale != sale || book.TotalBuy != buy {
+				t.Errorf("totals = %d/%d, want %d/%d", book.TotalSale, book.TotalBuy, sale, buy)
+			}
+			if got := book.BestAsk(); got != tt.ask {
+				t.Errorf("BestAsk = %d, want %d", got, tt.ask)
+			}
+			if got := book.BestBid(); got != tt.bid {
+				t.Errorf("BestBid = %d, want %d", got, tt.bid)
+			}
+			if got := book.Spread(); got != tt.sprd {
+				t.Errorf("Spread = %d, want %d", got, tt.sprd)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package bdoapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 일별 가격 하나
+type PricePoint struct {
+	Date  time.Time `json:"date"` // 지역 시간대 자정
+	Price int64     `json:"price"`
+}
+
+// GetMarketPriceInfo 응답, 오래된 날짜부터
+type PriceHistory struct {
+	ItemID int64        `json:"item_id"`
+	SubKey int          `json:"sub_key"`
+	Points []PricePoint `json:"points"`
+}
+
+func GetMarketPriceInfo(ctx context.Context, mainkey int, subkey int) (*PriceHistory, error) {
+	return defaultClient.GetMarketPriceInfo(ctx, mainkey, subkey)
+}
+
+// 가격 리스트 (price-price-...), 마지막 값이 오늘
+func (c *Client) GetMarketPriceInfo(ctx context.Context, mainkey int, subkey int) (*PriceHistory, error) {
+	resultMsg, err := doRequest(ctx, c, "GetMarketPriceInfo", MainSubKeyPayload{KeyType: 0, MainKey: mainkey, SubKey: subkey})
+	if err != nil {
+		return nil, fmt.Errorf("wrong request: [GetMarketPriceInfo] %d, %d: %w", mainkey, subkey, err)
+	}
+
+	parts := strings.Split(strings.TrimSuffix(resultMsg, "|"), "-")
+	if len(parts) == 1 && parts[0] == "" {
+		parts = nil
+	}
+	today := c.today()
+	hist := &PriceHistory{ItemID: int64(mainkey), SubKey: subkey, Points: make([]PricePoint, 0, len(parts))}
+	for idx, p := range parts {
+		price, err := strconv.ParseInt(p, 10, 64)
+		if err != nil {
+			return nil, recordErr("GetMarketPriceInfo", idx, "Price", p, err)
+		}
+		hist.Points = append(hist.Points, PricePoint{
+			Date:  today.AddDate(0, 0, idx-(len(parts)-1)),
+			Price: price,
+		})
+	}
+	return hist, nil
+}
+
+// sub list의 강화 단계마다 가격 기록 조회, key는 강화 단계(subKey)
+func (c *Client) GetPriceHistoryByEnhance(ctx context.Context, mainkey int) (map[int]*PriceHistory, error) {
+	levels, err := c.GetEnhanceLevels(ctx, mainkey, EnhanceOptions{PriceHistory: true})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[int]*PriceHistory, len(levels))
+	for _, lv := range levels {
+		if lv.Err != nil {
+			return nil, lv.Err
+		}
+		out[lv.Enhance] = lv.History
+	}
+	return out, nil
+}
+
+// 지역 시간대 기준 오늘 자정
+func (c *Client) today() time.Time {
+	now := time.Now()
+	if c.region.Location != nil {
+		now = now.In(c.region.Location)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
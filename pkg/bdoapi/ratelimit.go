@@ -0,0 +1,119 @@
+package bdoapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 요청 전 호출되는 리미터
+// 여러 레플리카 합산 제한이 필요하면 외부 구현(ex. repo의 pg advisory lock)을 끼움
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+const (
+	DefaultRatePerSecond = 10
+	DefaultRateBurst     = 10
+)
+
+// 토큰 버킷
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // 초당 토큰
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func NewTokenBucket(ratePerSecond float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		rate:   ratePerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// 토큰 1개를 예약하고, 필요한 만큼 대기
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if b.rate <= 0 {
+		return nil // 제한 없음
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		// 못 쓴 토큰 반납
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// 클라이언트 전체 초당 요청 수 (0이면 제한 없음)
+func WithRateLimit(ratePerSecond float64, burst int) Option {
+	return func(c *Client) { c.limiter = NewTokenBucket(ratePerSecond, burst) }
+}
+
+// 엔드포인트별 추가 제한, 전체 제한과 같이 적용됨
+func WithEndpointRateLimit(endpoint string, ratePerSecond float64, burst int) Option {
+	return WithEndpointLimiter(endpoint, NewTokenBucket(ratePerSecond, burst))
+}
+
+// 전체 제한을 직접 구현한 리미터로 교체
+func WithLimiter(l Limiter) Option {
+	return func(c *Client) { c.limiter = l }
+}
+
+// 여러 레플리카가 공유하는 예산 등, 전체 제한 뒤에 추가로 거치는 리미터
+func WithSharedLimiter(l Limiter) Option {
+	return func(c *Client) { c.shared = l }
+}
+
+func WithEndpointLimiter(endpoint string, l Limiter) Option {
+	return func(c *Client) {
+		if c.endpointLimiter == nil {
+			c.endpointLimiter = map[string]Limiter{}
+		}
+		c.endpointLimiter[endpoint] = l
+	}
+}
+
+// 요청 1회 전 대기: 엔드포인트 → 클라이언트 → 공유 예산 순
+func (c *Client) waitLimit(ctx context.Context, endpoint string) error {
+	limiters := []Limiter{c.endpointLimiter[endpoint], c.limiter, c.shared}
+	for _, l := range limiters {
+		if l == nil {
+			continue
+		}
+		if err := l.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
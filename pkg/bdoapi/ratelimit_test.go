@@ -0,0 +1,123 @@
+package bdoapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+)
+
+// n번 Wait하는 데 걸린 시간
+func waitN(t *testing.T, b *bdoapi.TokenBucket, n int) time.Duration {
+	t.Helper()
+	begin := time.Now()
+	for range n {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return time.Since(begin)
+}
+
+func TestTokenBucket(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     float64
+		burst    int
+		n        int
+		min, max time.Duration
+	}{
+		{name: "unlimited", rate: 0, burst: 1, n: 1000, max: 50 * time.Millisecond},
+		{name: "burst is free", rate: 10, burst: 5, n: 5, max: 50 * time.Millisecond},
+		// 버스트 다음부터는 1/rate 간격
+		{name: "after burst", rate: 10, burst: 2, n: 4, min: 180 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "burst below one", rate: 50, burst: 0, n: 6, min: 90 * time.Millisecond, max: 300 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := waitN(t, bdoapi.NewTokenBucket(tt.rate, tt.burst), tt.n)
+			if got < tt.min || got > tt.max {
+				t.Errorf("%d waits took %s, want %s..%s", tt.n, got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+// 취소된 대기는 토큰을 돌려줌
+func TestTokenBucketCancel(t *testing.T) {
+	b := bdoapi.NewTokenBucket(10, 1)
+	waitN(t, b, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	// 반납 안 하면 두 토큰분(200ms - 20ms)을 기다림
+	if got := waitN(t, b, 1); got > 130*time.Millisecond {
+		t.Errorf("wait after cancel took %s, want about 80ms", got)
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	m := fakemarket.Demo()
+	srv := fakemarket.Start(m)
+	t.Cleanup(srv.Close)
+	c := bdoapi.NewClient(
+		bdoapi.WithBaseURL(srv.URL+"/Trademarket/"),
+		bdoapi.WithRetryPolicy(bdoapi.NoRetry),
+		bdoapi.WithRateLimit(100, 1),
+		bdoapi.WithEndpointRateLimit("GetBiddingInfoList", 20, 1),
+	)
+	ctx := context.Background()
+
+	begin := time.Now()
+	for range 5 {
+		if _, err := c.GetMarketSubList(ctx, 6201); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := time.Since(begin); got < 35*time.Millisecond {
+		t.Errorf("5 requests at 100/s took %s", got)
+	}
+	// 엔드포인트 제한이 더 느리면 그쪽을 따름
+	begin = time.Now()
+	for range 5 {
+		if _, err := c.GetOrderBook(ctx, 6201, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := time.Since(begin); got < 180*time.Millisecond {
+		t.Errorf("5 requests at 20/s took %s", got)
+	}
+}
+
+// 서버가 긴 Retry-After를 줘도 MaxDelay까지만 기다림
+func TestRetryAfterCapped(t *testing.T) {
+	m := fakemarket.Demo()
+	srv := fakemarket.Start(m)
+	t.Cleanup(srv.Close)
+	c := bdoapi.NewClient(
+		bdoapi.WithBaseURL(srv.URL+"/Trademarket/"),
+		bdoapi.WithRetryPolicy(bdoapi.RetryPolicy{MaxAttempts: 2, BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}),
+		bdoapi.WithRateLimit(0, 1),
+	)
+	m.InjectFault("GetWorldMarketSubList", fakemarket.Fault{Status: http.StatusTooManyRequests, RetryAfter: time.Hour, Times: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	begin := time.Now()
+	if _, err := c.GetMarketSubList(ctx, 6201); err != nil {
+		t.Fatal(err)
+	}
+	if got := time.Since(begin); got < 40*time.Millisecond || got > time.Second {
+		t.Errorf("retried after %s, want about 50ms", got)
+	}
+	if n := m.Requests("GetWorldMarketSubList"); n != 2 {
+		t.Errorf("%d requests, want 2", n)
+	}
+}
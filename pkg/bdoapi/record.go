@@ -0,0 +1,267 @@
+package bdoapi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+	레코드 코덱
+	거래소 응답은 레코드를 '|', 필드를 '-'로 구분한 문자열
+	구조체 필드에 `rec` 태그로 위치를 적으면 디코딩/인코딩
+
+	  `rec:"0"`          : 0번째 필드
+	  `rec:"9,unix"`     : unix 초 → time.Time (0 이하면 zero time)
+	  `rec:"4,optional"` : 레코드에 없으면 zero 값 (뒤쪽 필드에만 가능)
+	  태그 없음 / `rec:"-"` : 무시
+
+	지원 타입: int*, uint*, bool(0/1), string, time.Time(unix)
+	위치는 0부터 빈 번호 없이 이어져야 함
+*/
+
+const (
+	RecordSep = "|"
+	FieldSep  = "-"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type recField struct {
+	index    int
+	name     string
+	pos      int
+	optional bool
+	unix     bool
+}
+
+type RecordCodec[T any] struct {
+	fields   []recField // pos 순
+	required int        // 최소 필드 수
+	loc      *time.Location
+}
+
+// T의 rec 태그를 검사해서 코덱 생성
+func NewRecordCodec[T any]() (*RecordCodec[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("record codec: %s is not a struct", t)
+	}
+
+	var fields []recField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("rec")
+		if !ok || tag == "-" {
+			continue
+		}
+		if !sf.IsExported() {
+			return nil, fmt.Errorf("record codec: %s.%s: unexported field", t, sf.Name)
+		}
+		opts := strings.Split(tag, ",")
+		pos, err := strconv.Atoi(opts[0])
+		if err != nil || pos < 0 {
+			return nil, fmt.Errorf("record codec: %s.%s: bad position %q", t, sf.Name, opts[0])
+		}
+		f := recField{index: i, name: sf.Name, pos: pos}
+		for _, o := range opts[1:] {
+			switch o {
+			case "optional":
+				f.optional = true
+			case "unix":
+				f.unix = true
+			default:
+				return nil, fmt.Errorf("record codec: %s.%s: unknown option %q", t, sf.Name, o)
+			}
+		}
+		if err := checkFieldType(sf.Type, f.unix); err != nil {
+			return nil, fmt.Errorf("record codec: %s.%s: %w", t, sf.Name, err)
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("record codec: %s has no rec fields", t)
+	}
+
+	sort.Slice(fields, func(i, j int) bool { return fields[i].pos < fields[j].pos })
+	required := len(fields)
+	for i, f := range fields {
+		if f.pos != i {
+			return nil, fmt.Errorf("record codec: %s: positions must be 0..%d without gaps", t, len(fields)-1)
+		}
+		if f.optional && required == len(fields) {
+			required = i
+		}
+		if !f.optional && required < len(fields) {
+			return nil, fmt.Errorf("record codec: %s.%s: required field after optional field", t, f.name)
+		}
+	}
+	return &RecordCodec[T]{fields: fields, required: required}, nil
+}
+
+// 패키지 변수 초기화용, 태그가 잘못됐으면 panic
+func MustRecordCodec[T any]() *RecordCodec[T] {
+	c, err := NewRecordCodec[T]()
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func checkFieldType(t reflect.Type, unix bool) error {
+	if unix {
+		if t != timeType {
+			return fmt.Errorf("unix option needs time.Time, got %s", t)
+		}
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Bool, reflect.String:
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", t)
+}
+
+// unix 필드를 loc 시간대로 디코딩하는 복사본
+func (c *RecordCodec[T]) In(loc *time.Location) *RecordCodec[T] {
+	cp := *c
+	cp.loc = loc
+	return &cp
+}
+
+// 레코드 하나의 최소/최대 필드 수
+func (c *RecordCodec[T]) Arity() (min, max int) {
+	return c.required, len(c.fields)
+}
+
+// 필드 이름 (pos 순)
+func (c *RecordCodec[T]) FieldNames() []string {
+	out := make([]string, len(c.fields))
+	for i, f := range c.fields {
+		out[i] = f.name
+	}
+	return out
+}
+
+// '|'로 구분된 전체 응답, 빈 레코드는 건너뜀
+func (c *RecordCodec[T]) Decode(endpoint, raw string) ([]T, error) {
+	parts := strings.Split(raw, RecordSep)
+	out := make([]T, 0, len(parts))
+	for idx, rec := range parts {
+		if rec == "" {
+			continue
+		}
+		v, err := c.DecodeRecord(endpoint, idx, rec)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// 레코드 하나, 에러는 *RecordError (idx는 에러 메시지용)
+func (c *RecordCodec[T]) DecodeRecord(endpoint string, idx int, rec string) (T, error) {
+	var v T
+	fs := strings.Split(rec, FieldSep)
+	if len(fs) < c.required || len(fs) > len(c.fields) {
+		return v, recordErr(endpoint, idx, "", rec, ErrFieldCount)
+	}
+	rv := reflect.ValueOf(&v).Elem()
+	for i, s := range fs {
+		f := c.fields[i]
+		if err := c.setField(rv.Field(f.index), f, s); err != nil {
+			return v, recordErr(endpoint, idx, f.name, rec, err)
+		}
+	}
+	return v, nil
+}
+
+func (c *RecordCodec[T]) setField(fv reflect.Value, f recField, s string) error {
+	if f.unix {
+		sec, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		if sec > 0 {
+			t := time.Unix(sec, 0)
+			if c.loc != nil {
+				t = t.In(c.loc)
+			}
+			fv.Set(reflect.ValueOf(t))
+		}
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Bool:
+		switch s {
+		case "0":
+			fv.SetBool(false)
+		case "1":
+			fv.SetBool(true)
+		default:
+			return fmt.Errorf("invalid bool %q", s)
+		}
+	case reflect.String:
+		fv.SetString(s)
+	}
+	return nil
+}
+
+// Decode의 역, 테스트 서버/녹화 데이터 생성용
+func (c *RecordCodec[T]) Encode(vs []T) string {
+	var b strings.Builder
+	for _, v := range vs {
+		b.WriteString(c.EncodeRecord(v))
+		b.WriteString(RecordSep)
+	}
+	return b.String()
+}
+
+// optional 필드도 항상 씀
+func (c *RecordCodec[T]) EncodeRecord(v T) string {
+	rv := reflect.ValueOf(v)
+	fs := make([]string, len(c.fields))
+	for i, f := range c.fields {
+		fv := rv.Field(f.index)
+		switch {
+		case f.unix:
+			t := fv.Interface().(time.Time)
+			if t.IsZero() {
+				fs[i] = "0"
+			} else {
+				fs[i] = strconv.FormatInt(t.Unix(), 10)
+			}
+		case fv.CanInt():
+			fs[i] = strconv.FormatInt(fv.Int(), 10)
+		case fv.CanUint():
+			fs[i] = strconv.FormatUint(fv.Uint(), 10)
+		case fv.Kind() == reflect.Bool:
+			if fv.Bool() {
+				fs[i] = "1"
+			} else {
+				fs[i] = "0"
+			}
+		default:
+			fs[i] = fv.String()
+		}
+	}
+	return strings.Join(fs, FieldSep)
+}
@@ -0,0 +1,222 @@
+package bdoapi_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi"
+)
+
+// 지원 타입 전부
+type allTypes struct {
+	ID     int64     `rec:"0"`
+	Grade  int8      `rec:"1"`
+	Count  uint16    `rec:"2"`
+	Sealed bool      `rec:"3"`
+	Name   string    `rec:"4"`
+	At     time.Time `rec:"5,unix"`
+	Note   string    `rec:"-"`
+	Extra  int32     `rec:"6,optional"`
+}
+
+func TestNewRecordCodecTags(t *testing.T) {
+	type notStruct int
+	type noFields struct{ A int }
+	type badPos struct {
+		A int `rec:"x"`
+	}
+	type negPos struct {
+		A int `rec:"-1"`
+	}
+	type unknownOpt struct {
+		A int `rec:"0,fast"`
+	}
+	type unixNotTime struct {
+		A int64 `rec:"0,unix"`
+	}
+	type unsupported struct {
+		A float64 `rec:"0"`
+	}
+	type gap struct {
+		A int `rec:"0"`
+		B int `rec:"2"`
+	}
+	type dupPos struct {
+		A int `rec:"0"`
+		B int `rec:"0"`
+	}
+	type requiredAfterOptional struct {
+		A int `rec:"0"`
+		B int `rec:"1,optional"`
+		C int `rec:"2"`
+	}
+	type unexported struct {
+		a int `rec:"0"`
+	}
+	_ = unexported{}.a
+
+	tests := []struct {
+		name string
+		new  func() error
+		want string // 빈 값이면 성공
+	}{
+		{"ok", func() error { _, err := bdoapi.NewRecordCodec[allTypes](); return err }, ""},
+		{"not a struct", func() error { _, err := bdoapi.NewRecordCodec[notStruct](); return err }, "is not a struct"},
+		{"no rec fields", func() error { _, err := bdoapi.NewRecordCodec[noFields](); return err }, "has no rec fields"},
+		{"bad position", func() error { _, err := bdoapi.NewRecordCodec[badPos](); return err }, `bad position "x"`},
+		{"negative position", func() error { _, err := bdoapi.NewRecordCodec[negPos](); return err }, `bad position "-1"`},
+		{"unknown option", func() error { _, err := bdoapi.NewRecordCodec[unknownOpt](); return err }, `unknown option "fast"`},
+		{"unix on int", func() error { _, err := bdoapi.NewRecordCodec[unixNotTime](); return err }, "unix option needs time.Time"},
+		{"unsupported type", func() error { _, err := bdoapi.NewRecordCodec[unsupported](); return err }, "unsupported type float64"},
+		{"gap", func() error { _, err := bdoapi.NewRecordCodec[gap](); return err }, "without gaps"},
+		{"duplicate position", func() error { _, err := bdoapi.NewRecordCodec[dupPos](); return err }, "without gaps"},
+		{"required after optional", func() error { _, err := bdoapi.NewRecordCodec[requiredAfterOptional](); return err }, "required field after optional"},
+		{"unexported", func() error { _, err := bdoapi.NewRecordCodec[unexported](); return err }, "unexported field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.new()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMustRecordCodecPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic")
+		}
+	}()
+	bdoapi.MustRecordCodec[struct{ A int }]()
+}
+
+func TestDecodeRecord(t *testing.T) {
+	codec := bdoapi.MustRecordCodec[allTypes]()
+	at := time.Unix(1772452620, 0)
+	tests := []struct {
+		name      string
+		raw       string
+		want      allTypes
+		wantField string // RecordError.Field, 필드 개수 오류면 빈 값
+		wantErr   error
+	}{
+		{name: "all fields", raw: "7-3-12-1-abc-1772452620-9", want: allTypes{ID: 7, Grade: 3, Count: 12, Sealed: true, Name: "abc", At: at, Extra: 9}},
+		{name: "optional missing", raw: "7-3-12-0-abc-1772452620", want: allTypes{ID: 7, Grade: 3, Count: 12, Name: "abc", At: at}},
+		{name: "zero time", raw: "7-3-12-0-abc-0", want: allTypes{ID: 7, Grade: 3, Count: 12, Name: "abc"}},
+		{name: "negative time", raw: "7-3-12-0-abc--1", wantField: "At", wantErr: strconv.ErrSyntax}, // 구분자가 '-'라 음수는 빈 필드가 됨
+		{name: "empty name", raw: "7-3-12-0--1772452620", want: allTypes{ID: 7, Grade: 3, Count: 12, At: at}},
+		{name: "short", raw: "7-3-12-0", wantErr: bdoapi.ErrFieldCount},
+		{name: "long", raw: "7-3-12-0-abc-1772452620-9-10", wantErr: bdoapi.ErrFieldCount},
+		{name: "empty field", raw: "-3-12-0-abc-1772452620", wantField: "ID", wantErr: strconv.ErrSyntax},
+		{name: "not a number", raw: "x-3-12-0-abc-1772452620", wantField: "ID", wantErr: strconv.ErrSyntax},
+		{name: "int8 overflow", raw: "7-300-12-0-abc-1772452620", wantField: "Grade", wantErr: strconv.ErrRange},
+		{name: "negative uint", raw: "7-3--1-0-abc-1772452620", wantField: "Count", wantErr: strconv.ErrSyntax},
+		{name: "uint overflow", raw: "7-3-70000-0-abc-1772452620", wantField: "Count", wantErr: strconv.ErrRange},
+		{name: "bad bool", raw: "7-3-12-2-abc-1772452620", wantField: "Sealed"},
+		{name: "bad time", raw: "7-3-12-0-abc-soon", wantField: "At", wantErr: strconv.ErrSyntax},
+		{name: "bad optional", raw: "7-3-12-0-abc-1772452620-x", wantField: "Extra", wantErr: strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := codec.DecodeRecord("Test", 4, tt.raw)
+			if tt.wantErr == nil && tt.wantField == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !got.At.Equal(tt.want.At) {
+					t.Errorf("At = %v, want %v", got.At, tt.want.At)
+				}
+				got.At, tt.want.At = time.Time{}, time.Time{}
+				if got != tt.want {
+					t.Errorf("got %+v, want %+v", got, tt.want)
+				}
+				return
+			}
+			var re *bdoapi.RecordError
+			if !errors.As(err, &re) {
+				t.Fatalf("err = %v (%T), want *RecordError", err, err)
+			}
+			if re.Endpoint != "Test" || re.Index != 4 || re.Raw != tt.raw || re.Field != tt.wantField {
+				t.Errorf("RecordError = %+v, want field %q", re, tt.wantField)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	codec := bdoapi.MustRecordCodec[bdoapi.MarketListObject]()
+
+	got, err := codec.Decode("Test", "|1-2-3-4||5-6-7-8|")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []bdoapi.MarketListObject{{ItemID: 1, CurrentStock: 2, TotalTrades: 3, BasePrice: 4}, {ItemID: 5, CurrentStock: 6, TotalTrades: 7, BasePrice: 8}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Decode = %+v, want %+v", got, want)
+	}
+
+	if got, err := codec.Decode("Test", ""); err != nil || len(got) != 0 {
+		t.Errorf("Decode empty = %+v, %v", got, err)
+	}
+
+	// 빈 레코드도 번호는 셈
+	_, err = codec.Decode("Test", "1-2-3-4||5-6-7")
+	var re *bdoapi.RecordError
+	if !errors.As(err, &re) || re.Index != 2 || !errors.Is(err, bdoapi.ErrFieldCount) {
+		t.Errorf("err = %v, want field count error at record 2", err)
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec := bdoapi.MustRecordCodec[allTypes]().In(seoul)
+	tests := []struct {
+		name string
+		in   []allTypes
+	}{
+		{"empty", nil},
+		{"zero", []allTypes{{}}},
+		// 구분자가 '-'라 음수는 왕복 불가
+		{"several", []allTypes{
+			{ID: 1, Grade: 5, Count: 65535, Sealed: true, Name: "x", At: time.Unix(1772452620, 0).In(seoul), Extra: 1},
+			{ID: 9_000_000_000, Grade: 127, Name: "사슴 피", At: time.Unix(1, 0).In(seoul)},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := codec.Encode(tt.in)
+			got, err := codec.Decode("Test", raw)
+			if err != nil {
+				t.Fatalf("Decode(%q): %v", raw, err)
+			}
+			if len(got) != len(tt.in) {
+				t.Fatalf("Decode(%q) = %d records, want %d", raw, len(got), len(tt.in))
+			}
+			for i := range got {
+				if got[i].At.Location() != tt.in[i].At.Location() && !tt.in[i].At.IsZero() {
+					t.Errorf("record %d: location %v, want %v", i, got[i].At.Location(), tt.in[i].At.Location())
+				}
+				if got[i] != tt.in[i] {
+					t.Errorf("record %d = %+v, want %+v", i, got[i], tt.in[i])
+				}
+			}
+		})
+	}
+}
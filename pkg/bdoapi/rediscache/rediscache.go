@@ -0,0 +1,332 @@
+// Redis(및 RESP 호환 서버: KeyDB, Valkey, Dragonfly 등) 응답 캐시 백엔드
+// 여러 서버 레플리카가 같은 캐시를 쓸 때
+//
+//	rc, _ := rediscache.New("redis://:pass@localhost:6379/0")
+//	client := bdoapi.NewClient(bdoapi.WithCache(rc))
+//
+// TLS는 rediss:// (관리형 redis 등), 인증서를 직접 지정하려면 WithTLSConfig
+//
+// GET / SET PX 만 쓰므로 RESP2 를 직접 구현 (외부 의존성 없음)
+package rediscache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultPoolSize    = 8
+	DefaultDialTimeout = 2 * time.Second
+)
+
+// 서버가 돌려준 -ERR 응답
+type ServerError string
+
+func (e ServerError) Error() string { return "redis: " + string(e) }
+
+var ErrClosed = errors.New("redis: cache closed")
+
+type Cache struct {
+	addr     string
+	password string
+	username string
+	db       int
+	prefix   string
+	timeout  time.Duration
+	tls      *tls.Config // nil이면 평문
+	pool     chan *conn
+
+	mu     sync.Mutex
+	closed bool
+}
+
+type Option func(*Cache)
+
+// 모든 키 앞에 붙임 (같은 redis를 다른 용도와 같이 쓸 때)
+func WithKeyPrefix(p string) Option {
+	return func(c *Cache) { c.prefix = p }
+}
+
+// 보관할 유휴 커넥션 수
+func WithPoolSize(n int) Option {
+	return func(c *Cache) {
+		if n > 0 {
+			c.pool = make(chan *conn, n)
+		}
+	}
+}
+
+// 연결/명령 하나의 제한 시간 (ctx 데드라인이 더 짧으면 그쪽)
+func WithTimeout(d time.Duration) Option {
+	return func(c *Cache) { c.timeout = d }
+}
+
+// TLS 설정 (CA/클라이언트 인증서 등), redis:// 여도 TLS로 연결
+// ServerName이 비어 있으면 URL의 호스트
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Cache) { c.tls = cfg.Clone() }
+}
+
+// rawURL: redis[s]://[user:password@]host[:port][/db], 스킴 없이 host:port 도 됨
+// 연결은 처음 쓸 때 맺음
+func New(rawURL string, opts ...Option) (*Cache, error) {
+	c := &Cache{timeout: DefaultDialTimeout, pool: make(chan *conn, DefaultPoolSize)}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "redis://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("redis url: %w", err)
+	}
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		c.tls = &tls.Config{}
+	default:
+		return nil, fmt.Errorf("redis url: unsupported scheme %q", u.Scheme)
+	}
+	c.addr = u.Host
+	if u.Port() == "" {
+		c.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+		// redis://:pass@host 형태는 비밀번호만
+		if _, ok := u.User.Password(); !ok {
+			c.password, c.username = c.username, ""
+		}
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		n, err := strconv.Atoi(db)
+		if err != nil {
+			return nil, fmt.Errorf("redis url: db %q: %w", db, err)
+		}
+		c.db = n
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.tls != nil && c.tls.ServerName == "" {
+		c.tls.ServerName = u.Hostname()
+	}
+	return c, nil
+}
+
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	v, err := c.do(ctx, "GET", c.prefix+key)
+	if err != nil {
+		return nil, false, err
+	}
+	if v == nil {
+		return nil, false, nil
+	}
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: GET: unexpected reply %T", v)
+	}
+	return b, true, nil
+}
+
+// ttl은 밀리초 단위로 반올림, 0 이하면 만료 없음
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", c.prefix + key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	}
+	_, err := c.do(ctx, args...)
+	return err
+}
+
+// 연결 확인용
+func (c *Cache) Ping(ctx context.Context) error {
+	_, err := c.do(ctx, "PING")
+	return err
+}
+
+// 유휴 커넥션 정리, 이후 요청은 ErrClosed
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	for {
+		select {
+		case cn := <-c.pool:
+			cn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (c *Cache) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// 명령 하나, 서버 -ERR 이 아닌 에러가 나면 커넥션은 버림
+func (c *Cache) do(ctx context.Context, args ...string) (any, error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	cn.SetDeadline(deadline)
+	v, err := cn.cmd(args...)
+	var se ServerError
+	if err != nil && !errors.As(err, &se) {
+		cn.Close()
+		return nil, err
+	}
+	c.put(cn)
+	return v, err
+}
+
+func (c *Cache) get(ctx context.Context) (*conn, error) {
+	if c.isClosed() {
+		return nil, ErrClosed
+	}
+	select {
+	case cn := <-c.pool:
+		return cn, nil
+	default:
+	}
+	nc, err := c.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("redis: dial %s: %w", c.addr, err)
+	}
+	cn := &conn{Conn: nc, r: bufio.NewReader(nc), w: bufio.NewWriter(nc)}
+	nc.SetDeadline(time.Now().Add(c.timeout))
+	if c.password != "" {
+		args := []string{"AUTH", c.password}
+		if c.username != "" {
+			args = []string{"AUTH", c.username, c.password}
+		}
+		if _, err := cn.cmd(args...); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+	if c.db != 0 {
+		if _, err := cn.cmd("SELECT", strconv.Itoa(c.db)); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+	return cn, nil
+}
+
+func (c *Cache) dial(ctx context.Context) (net.Conn, error) {
+	d := &net.Dialer{Timeout: c.timeout}
+	if c.tls == nil {
+		return d.DialContext(ctx, "tcp", c.addr)
+	}
+	td := &tls.Dialer{NetDialer: d, Config: c.tls}
+	return td.DialContext(ctx, "tcp", c.addr)
+}
+
+func (c *Cache) put(cn *conn) {
+	if c.isClosed() {
+		cn.Close()
+		return
+	}
+	select {
+	case c.pool <- cn:
+	default:
+		cn.Close()
+	}
+}
+
+/* ---------- RESP2 ---------- */
+
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// *N\r\n $len\r\n arg\r\n ...
+func (cn *conn) cmd(args ...string) (any, error) {
+	fmt.Fprintf(cn.w, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(cn.w, "$%d\r\n%s\r\n", len(a), a)
+	}
+	if err := cn.w.Flush(); err != nil {
+		return nil, err
+	}
+	return cn.reply()
+}
+
+// 문자열/숫자/bulk(nil 가능)/배열
+func (cn *conn) reply() (any, error) {
+	line, err := cn.line()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, ServerError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: bulk length %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		b := make([]byte, n+2)
+		if _, err := io.ReadFull(cn.r, b); err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: array length %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		out := make([]any, n)
+		for i := range out {
+			if out[i], err = cn.reply(); err != nil {
+				var se ServerError
+				if !errors.As(err, &se) {
+					return nil, err
+				}
+				out[i] = se
+			}
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("redis: unexpected reply %q", line)
+}
+
+func (cn *conn) line() (string, error) {
+	s, err := cn.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(s, "\n"), "\r"), nil
+}
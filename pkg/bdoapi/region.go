@@ -0,0 +1,93 @@
+package bdoapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	_ "time/tzdata" // distroless 이미지에는 zoneinfo가 없음
+)
+
+var ErrUnknownRegion = errors.New("unknown region")
+
+// 지역별 거래소 정보
+type Region struct {
+	Code     string // kr, na, eu ...
+	Name     string
+	Host     string
+	TimeZone string
+	Location *time.Location
+	Lang     string // 아이템 이름 표시 언어
+	Currency string // 유료 상점(펄) 결제 통화, ISO 4217 (가방 가격 환산용)
+}
+
+// 판매 수수료/가방(밸류 패키지) 보너스, 지역별 차이가 없어 공통값
+const (
+	MarketTaxRate  = 0.35
+	ValuePackBonus = 0.30
+)
+
+// 거래소 API base url
+func (r Region) BaseURL() string {
+	return "https://" + r.Host + "/Trademarket/"
+}
+
+// 판매 대금 중 실제 손에 들어오는 비율
+// ex) 가방 + 가문명성 1.5% = (1-0.35)*(1+0.3+0.015) = 0.85475
+func SellMultiplier(valuePack bool, fameBonus float64) float64 {
+	bonus := fameBonus
+	if valuePack {
+		bonus += ValuePackBonus
+	}
+	return (1 - MarketTaxRate) * (1 + bonus)
+}
+
+// 등록된 지역 목록 (defaultClient 초기화보다 먼저 채워져야 하므로 init() 대신 변수 초기화)
+var regions = func() map[string]Region {
+	m := map[string]Region{}
+	for _, r := range []Region{
+		{Code: "kr", Name: "Korea", Host: "trade.kr.playblackdesert.com", TimeZone: "Asia/Seoul", Lang: "ko", Currency: "KRW"},
+		{Code: "na", Name: "North America", Host: "na-trade.naeu.playblackdesert.com", TimeZone: "America/Los_Angeles", Lang: "en", Currency: "USD"},
+		{Code: "eu", Name: "Europe", Host: "eu-trade.naeu.playblackdesert.com", TimeZone: "Europe/Berlin", Lang: "en", Currency: "EUR"},
+		{Code: "sea", Name: "South East Asia", Host: "trade.sea.playblackdesert.com", TimeZone: "Asia/Singapore", Lang: "en", Currency: "USD"},
+		{Code: "jp", Name: "Japan", Host: "trade.jp.playblackdesert.com", TimeZone: "Asia/Tokyo", Lang: "ja", Currency: "JPY"},
+		{Code: "tw", Name: "Taiwan", Host: "trade.tw.playblackdesert.com", TimeZone: "Asia/Taipei", Lang: "zh", Currency: "TWD"},
+		{Code: "sa", Name: "South America", Host: "blackdesert-tradeweb.playredfox.com", TimeZone: "America/Sao_Paulo", Lang: "pt", Currency: "BRL"},
+		{Code: "mena", Name: "Middle East & North Africa", Host: "trade.tr.playblackdesert.com", TimeZone: "Europe/Istanbul", Lang: "tr", Currency: "USD"},
+	} {
+		registerRegion(m, r)
+	}
+	return m
+}()
+
+// TimeZone으로 Location 채움
+func registerRegion(m map[string]Region, r Region) {
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		panic(fmt.Sprintf("bdoapi: region %s: %v", r.Code, err))
+	}
+	r.Location = loc
+	m[r.Code] = r
+}
+
+const DefaultRegion = "kr"
+
+// 대소문자 무시
+func LookupRegion(code string) (Region, error) {
+	r, ok := regions[strings.ToLower(strings.TrimSpace(code))]
+	if !ok {
+		return Region{}, fmt.Errorf("%w: %q", ErrUnknownRegion, code)
+	}
+	return r, nil
+}
+
+// 코드 순 정렬
+func Regions() []Region {
+	out := make([]Region, 0, len(regions))
+	for _, r := range regions {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
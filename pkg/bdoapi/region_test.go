@@ -0,0 +1,59 @@
+package bdoapi_test
+
+import (
+	"math"
+	"testing"
+
+	"bdo_calc_go/pkg/bdoapi"
+)
+
+func TestClientBaseURL(t *testing.T) {
+	na, err := bdoapi.LookupRegion("NA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kr, _ := bdoapi.LookupRegion(bdoapi.DefaultRegion)
+	const custom = "http://127.0.0.1:8080/Trademarket/"
+
+	tests := []struct {
+		name       string
+		opts       []bdoapi.Option
+		wantURL    string
+		wantRegion string
+	}{
+		{name: "default", wantURL: kr.BaseURL(), wantRegion: "kr"},
+		{name: "region", opts: []bdoapi.Option{bdoapi.WithRegion(na)}, wantURL: na.BaseURL(), wantRegion: "na"},
+		{name: "base url", opts: []bdoapi.Option{bdoapi.WithBaseURL(custom)}, wantURL: custom, wantRegion: "kr"},
+		// 옵션 순서와 상관없이 명시한 base url 우선
+		{name: "base url then region", opts: []bdoapi.Option{bdoapi.WithBaseURL(custom), bdoapi.WithRegion(na)}, wantURL: custom, wantRegion: "na"},
+		{name: "region then base url", opts: []bdoapi.Option{bdoapi.WithRegion(na), bdoapi.WithBaseURL(custom)}, wantURL: custom, wantRegion: "na"},
+		{name: "empty base url ignored", opts: []bdoapi.Option{bdoapi.WithBaseURL(""), bdoapi.WithRegion(na)}, wantURL: na.BaseURL(), wantRegion: "na"},
+		{name: "trailing slash", opts: []bdoapi.Option{bdoapi.WithBaseURL("http://127.0.0.1:8080/Trademarket")}, wantURL: custom, wantRegion: "kr"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := bdoapi.NewClient(tt.opts...)
+			if c.BaseURL() != tt.wantURL || c.Region().Code != tt.wantRegion {
+				t.Errorf("base url %s region %s, want %s %s", c.BaseURL(), c.Region().Code, tt.wantURL, tt.wantRegion)
+			}
+		})
+	}
+}
+
+func TestSellMultiplier(t *testing.T) {
+	tests := []struct {
+		valuePack bool
+		fame      float64
+		want      float64
+	}{
+		{false, 0, 0.65},
+		{true, 0, 0.845},
+		{true, 0.015, 0.85475},
+		{false, 0.015, 0.65975},
+	}
+	for _, tt := range tests {
+		if got := bdoapi.SellMultiplier(tt.valuePack, tt.fame); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("SellMultiplier(%v, %v) = %v, want %v", tt.valuePack, tt.fame, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package bdoapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// 재시도 정책
+// 거래소 API는 전부 조회성 POST라 엔드포인트 단위로 켜고 끔
+type RetryPolicy struct {
+	MaxAttempts int // 1 이하면 재시도 없음
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration // 0이면 DefaultRetryPolicy.MaxDelay
+	Jitter      float64       // 0~1, 대기시간에서 랜덤으로 빼는 비율
+	// nil이면 IsRetryable 사용
+	Retryable func(error) bool
+}
+
+var (
+	DefaultRetryPolicy = RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.5,
+	}
+	NoRetry = RetryPolicy{MaxAttempts: 1}
+)
+
+// 모든 엔드포인트 기본 정책
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// 특정 엔드포인트만 다른 정책 (ex. "GetBiddingInfoList")
+func WithEndpointRetryPolicy(endpoint string, p RetryPolicy) Option {
+	return func(c *Client) {
+		if c.endpointRetry == nil {
+			c.endpointRetry = map[string]RetryPolicy{}
+		}
+		c.endpointRetry[endpoint] = p
+	}
+}
+
+func (c *Client) retryPolicy(endpoint string) RetryPolicy {
+	if p, ok := c.endpointRetry[endpoint]; ok {
+		return p
+	}
+	return c.retry
+}
+
+// 재시도까지 실패했을 때 시도 횟수를 담아 반환
+type RetryError struct {
+	Endpoint string
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s: failed after %d attempt(s): %v", e.Endpoint, e.Attempts, e.Err)
+}
+func (e *RetryError) Unwrap() error { return e.Err }
+
+// 에러에 기록된 시도 횟수, 재시도 정보가 없으면 1
+func Attempts(err error) int {
+	var re *RetryError
+	if errors.As(err, &re) {
+		return re.Attempts
+	}
+	return 1
+}
+
+// MaxDelay가 없으면 기본 정책의 상한
+func (p RetryPolicy) maxDelay() time.Duration {
+	if p.MaxDelay <= 0 {
+		return DefaultRetryPolicy.MaxDelay
+	}
+	return p.MaxDelay
+}
+
+// n번째(1부터) 실패 후 대기 시간, shift가 넘칠 만큼 커지면 상한으로
+func (p RetryPolicy) backoff(n int) time.Duration {
+	maxDelay := p.maxDelay()
+	d := maxDelay
+	if shift := n - 1; shift < 63 && p.BaseDelay > 0 && p.BaseDelay <= maxDelay>>shift {
+		d = p.BaseDelay << shift
+	}
+	if p.Jitter > 0 && d > 0 {
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// n번째 실패 후 실제 대기 시간
+// 서버가 Retry-After를 backoff보다 길게 주면 따르되 MaxDelay를 넘지 않음
+func (p RetryPolicy) delay(n int, err error) time.Duration {
+	d := p.backoff(n)
+	var se *StatusError
+	if errors.As(err, &se) && se.RetryAfter > d {
+		d = min(se.RetryAfter, p.maxDelay())
+	}
+	return d
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// 정책에 따라 fn 반복 실행
+func withRetry[R any](ctx context.Context, c *Client, endpoint string, fn func() (R, error)) (R, error) {
+	p := c.retryPolicy(endpoint)
+	if p.MaxAttempts <= 1 {
+		return fn()
+	}
+
+	var zero R
+	for attempt := 1; ; attempt++ {
+		data, err := fn()
+		if err == nil {
+			return data, nil
+		}
+		if attempt >= p.MaxAttempts || !p.retryable(err) {
+			return zero, &RetryError{Endpoint: endpoint, Attempts: attempt, Err: err}
+		}
+
+		t := time.NewTimer(p.delay(attempt, err))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return zero, &RetryError{Endpoint: endpoint, Attempts: attempt, Err: fmt.Errorf("%s: %w", endpoint, ctx.Err())}
+		case <-t.C:
+		}
+	}
+}
+
+// Retry-After 헤더 (초 또는 HTTP-date)
+func parseRetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if sec, err := strconv.Atoi(v); err == nil && sec > 0 {
+		return time.Duration(sec) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
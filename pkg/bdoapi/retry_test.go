@@ -0,0 +1,65 @@
+package bdoapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name string
+		p    RetryPolicy
+		n    int
+		want time.Duration
+	}{
+		{"first", RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute}, 1, time.Second},
+		{"doubles", RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute}, 3, 4 * time.Second},
+		{"capped", RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute}, 10, time.Minute},
+		{"shift overflow", RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute}, 100, time.Minute},
+		{"no max delay", RetryPolicy{BaseDelay: time.Second}, 2, 2 * time.Second},
+		// 예전에는 여기서 0이 되어 대기 없이 재시도
+		{"no max delay overflow", RetryPolicy{BaseDelay: time.Second}, 100, DefaultRetryPolicy.MaxDelay},
+		{"no max delay large n", RetryPolicy{BaseDelay: time.Second}, 40, DefaultRetryPolicy.MaxDelay},
+		{"no base delay", RetryPolicy{MaxDelay: time.Minute}, 1, time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.backoff(tt.n); got != tt.want {
+				t.Errorf("backoff(%d) = %s, want %s", tt.n, got, tt.want)
+			}
+		})
+	}
+
+	// jitter는 상한 아래로만
+	p := RetryPolicy{BaseDelay: time.Second, Jitter: 0.5}
+	for range 100 {
+		if d := p.backoff(100); d <= 0 || d > DefaultRetryPolicy.MaxDelay {
+			t.Fatalf("jittered backoff = %s", d)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+	tests := []struct {
+		name string
+		p    RetryPolicy
+		err  error
+		want time.Duration
+	}{
+		{"no retry after", p, &StatusError{StatusCode: 500}, time.Second},
+		{"shorter retry after", p, &StatusError{StatusCode: 429, RetryAfter: 500 * time.Millisecond}, time.Second},
+		{"longer retry after", p, &StatusError{StatusCode: 429, RetryAfter: 7 * time.Second}, 7 * time.Second},
+		{"retry after capped", p, &StatusError{StatusCode: 429, RetryAfter: time.Hour}, 10 * time.Second},
+		{"wrapped", p, &RetryError{Err: &StatusError{StatusCode: 503, RetryAfter: time.Hour}}, 10 * time.Second},
+		{"default cap", RetryPolicy{BaseDelay: time.Second}, &StatusError{StatusCode: 429, RetryAfter: time.Hour}, DefaultRetryPolicy.MaxDelay},
+		{"not a status error", p, &TransportError{}, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.delay(1, tt.err); got != tt.want {
+				t.Errorf("delay = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package bdoapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrEmptySearch = errors.New("empty search")
+
+func SearchMarket(ctx context.Context, itemIDs ...int) ([]MarketListObject, error) {
+	return defaultClient.SearchMarket(ctx, itemIDs...)
+}
+
+// 카테고리를 몰라도 id로 바로 조회, 응답은 market list와 같은 형식
+func (c *Client) SearchMarket(ctx context.Context, itemIDs ...int) ([]MarketListObject, error) {
+	if len(itemIDs) == 0 {
+		return nil, ErrEmptySearch
+	}
+	query := searchQuery(itemIDs)
+
+	rawStr, err := doRequest(ctx, c, "GetWorldMarketSearchList", SearchPayload{SearchResult: query})
+	if err != nil {
+		return nil, fmt.Errorf("wrong request: [GetWorldMarketSearchList] %s: %w", query, err)
+	}
+	return c.parseMarketList("GetWorldMarketSearchList", rawStr)
+}
+
+// 15720,4901 형식
+func searchQuery(itemIDs []int) string {
+	ids := make([]string, len(itemIDs))
+	for i, id := range itemIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+	return strings.Join(ids, ",")
+}
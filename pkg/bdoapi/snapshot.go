@@ -0,0 +1,242 @@
+package bdoapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+	여러 카테고리 한 번에 조회 (Snapshot)
+	  1. 카테고리마다 GetWorldMarketList
+	  2. 고른 아이템마다 sub list / order book
+	둘 다 워커 Workers개가 나눠서 처리, 요청 속도는 클라이언트 리미터(WithRateLimit)를 그대로 따름
+	기본 10req/s 기준 2분이면 ~1200 요청, 상세 조회할 아이템 수를 그 안으로 고를 것
+*/
+
+const (
+	DefaultSnapshotWorkers = 8
+	DefaultSnapshotTimeout = 90 * time.Second
+)
+
+var ErrSnapshotTimeout = errors.New("snapshot timeout")
+
+type SnapshotOptions struct {
+	Categories []Category // 비어 있으면 전체 (Categories().All())
+	// 상세 조회할 아이템: Items + Select가 true인 목록 아이템
+	Items      []int64
+	Select     func(Category, MarketListObject) bool
+	SubLists   bool          // sub list (강화 단계별 기준가/재고)
+	OrderBooks bool          // 0강 호가
+	Workers    int           // 0이면 DefaultSnapshotWorkers
+	Timeout    time.Duration // 0이면 DefaultSnapshotTimeout, 넘기면 남은 건 ErrSnapshotTimeout
+}
+
+// 실패한 조회 하나
+type SnapshotError struct {
+	Stage    string `json:"stage"` // list, sub_list, order_book, item(시작 못 함)
+	Category string `json:"category,omitempty"`
+	ItemID   int64  `json:"item_id,omitempty"`
+	Err      error  `json:"-"`
+}
+
+func (e *SnapshotError) Error() string {
+	if e.Category != "" {
+		return fmt.Sprintf("snapshot %s %s: %v", e.Stage, e.Category, e.Err)
+	}
+	return fmt.Sprintf("snapshot %s %d: %v", e.Stage, e.ItemID, e.Err)
+}
+
+func (e *SnapshotError) Unwrap() error { return e.Err }
+
+// 아이템 하나, 여러 카테고리에 나와도 하나 (처음 나온 카테고리)
+type ItemSnapshot struct {
+	ItemID    int64                 `json:"item_id"`
+	Category  string                `json:"category,omitempty"` // Items로만 지정돼 목록에 없으면 빈 값
+	Listing   *MarketListObject     `json:"listing,omitempty"`
+	SubList   []MarketSubListObject `json:"sub_list,omitempty"`
+	OrderBook *OrderBook            `json:"order_book,omitempty"`
+	Errors    []*SnapshotError      `json:"errors,omitempty"`
+}
+
+func (it *ItemSnapshot) OK() bool { return len(it.Errors) == 0 }
+
+// 조회 결과 전체, 레코드는 전부 At 시점 것으로 취급
+type MarketSnapshot struct {
+	Region     string                        `json:"region"`
+	At         time.Time                     `json:"at"` // 시작 시각
+	Elapsed    time.Duration                 `json:"elapsed"`
+	Lists      map[string][]MarketListObject `json:"lists"` // 카테고리 Path → 목록
+	Items      []*ItemSnapshot               `json:"items"` // id 순, 상세 조회한 아이템만
+	ListErrors []*SnapshotError              `json:"list_errors,omitempty"`
+}
+
+// 아이템 에러 + 카테고리 에러 전부
+func (s *MarketSnapshot) Errors() []*SnapshotError {
+	out := append([]*SnapshotError(nil), s.ListErrors...)
+	for _, it := range s.Items {
+		out = append(out, it.Errors...)
+	}
+	return out
+}
+
+func (s *MarketSnapshot) Item(id int64) (*ItemSnapshot, bool) {
+	i := sort.Search(len(s.Items), func(i int) bool { return s.Items[i].ItemID >= id })
+	if i < len(s.Items) && s.Items[i].ItemID == id {
+		return s.Items[i], true
+	}
+	return nil, false
+}
+
+func Snapshot(ctx context.Context, opts SnapshotOptions) (*MarketSnapshot, error) {
+	return defaultClient.Snapshot(ctx, opts)
+}
+
+// 개별 실패는 스냅샷 안의 에러로, 반환 에러는 ctx가 취소됐을 때만
+// (타임아웃은 남은 조회를 ErrSnapshotTimeout으로 채우고 정상 반환)
+func (c *Client) Snapshot(ctx context.Context, opts SnapshotOptions) (*MarketSnapshot, error) {
+	cats := opts.Categories
+	if len(cats) == 0 {
+		cats = Categories().All()
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultSnapshotWorkers
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultSnapshotTimeout
+	}
+	snap := &MarketSnapshot{Region: c.region.Code, At: time.Now(), Lists: map[string][]MarketListObject{}}
+	runCtx, cancel := context.WithTimeoutCause(ctx, timeout, ErrSnapshotTimeout)
+	defer cancel()
+
+	// 1. 목록
+	lists := make([][]MarketListObject, len(cats))
+	listErrs := make([]error, len(cats))
+	listDone := make([]bool, len(cats))
+	runPool(runCtx, workers, len(cats), func(i int) {
+		lists[i], listErrs[i] = c.GetMarketListCategory(runCtx, cats[i])
+		listDone[i] = true
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	items := map[int64]*ItemSnapshot{}
+	for i, cat := range cats {
+		if !listDone[i] || listErrs[i] != nil {
+			snap.ListErrors = append(snap.ListErrors, &SnapshotError{Stage: "list", Category: cat.Path(), Err: snapshotErr(runCtx, listErrs[i])})
+			continue
+		}
+		snap.Lists[cat.Path()] = lists[i]
+		for j := range lists[i] {
+			o := &lists[i][j]
+			if _, dup := items[o.ItemID]; dup || opts.Select == nil || !opts.Select(cat, *o) {
+				continue
+			}
+			items[o.ItemID] = &ItemSnapshot{ItemID: o.ItemID, Category: cat.Path(), Listing: o}
+		}
+	}
+	for _, id := range opts.Items {
+		if _, ok := items[id]; !ok {
+			items[id] = snap.listing(cats, id)
+		}
+	}
+	snap.Items = make([]*ItemSnapshot, 0, len(items))
+	for _, it := range items {
+		snap.Items = append(snap.Items, it)
+	}
+	sort.Slice(snap.Items, func(i, j int) bool { return snap.Items[i].ItemID < snap.Items[j].ItemID })
+
+	// 2. 상세, 아이템 하나를 워커 하나가 끝까지
+	if opts.SubLists || opts.OrderBooks {
+		itemDone := make([]bool, len(snap.Items))
+		runPool(runCtx, workers, len(snap.Items), func(i int) {
+			c.snapshotItem(runCtx, snap.Items[i], opts)
+			itemDone[i] = true
+		})
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// 시간 안에 시작도 못 한 아이템
+		for i, it := range snap.Items {
+			if !itemDone[i] {
+				it.Errors = append(it.Errors, &SnapshotError{Stage: "item", ItemID: it.ItemID, Err: snapshotErr(runCtx, nil)})
+			}
+		}
+	}
+	snap.Elapsed = time.Since(snap.At)
+	return snap, nil
+}
+
+func (c *Client) snapshotItem(ctx context.Context, it *ItemSnapshot, opts SnapshotOptions) {
+	id := int(it.ItemID)
+	if opts.SubLists {
+		subs, err := c.GetMarketSubList(ctx, id)
+		if err != nil {
+			it.Errors = append(it.Errors, &SnapshotError{Stage: "sub_list", ItemID: it.ItemID, Err: snapshotErr(ctx, err)})
+		} else {
+			it.SubList = subs
+		}
+	}
+	if opts.OrderBooks {
+		book, err := c.GetOrderBook(ctx, id, 0)
+		if err != nil {
+			it.Errors = append(it.Errors, &SnapshotError{Stage: "order_book", ItemID: it.ItemID, Err: snapshotErr(ctx, err)})
+		} else {
+			it.OrderBook = book
+		}
+	}
+}
+
+// 0..n-1을 워커 n개가 나눠 처리, ctx가 끝나면 남은 건 건너뜀
+func runPool(ctx context.Context, workers, n int, fn func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+send:
+	for i := range n {
+		select {
+		case <-ctx.Done():
+			break send
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// 타임아웃으로 끝난 요청/건너뛴 조회는 ErrSnapshotTimeout
+func snapshotErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil && (err == nil || errors.Is(err, context.DeadlineExceeded)) {
+		if cause := context.Cause(ctx); cause != nil {
+			return cause
+		}
+	}
+	return err
+}
+
+// Items로 지정한 아이템, 목록에 있으면 그 카테고리 (cats 순서로 처음 나온 것)
+func (s *MarketSnapshot) listing(cats []Category, id int64) *ItemSnapshot {
+	for _, cat := range cats {
+		list := s.Lists[cat.Path()]
+		for i := range list {
+			if list[i].ItemID == id {
+				return &ItemSnapshot{ItemID: id, Category: cat.Path(), Listing: &list[i]}
+			}
+		}
+	}
+	return &ItemSnapshot{ItemID: id}
+}
@@ -0,0 +1,187 @@
+package bdoapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+)
+
+func lookupCategories(t *testing.T, paths ...string) []bdoapi.Category {
+	t.Helper()
+	cats := make([]bdoapi.Category, 0, len(paths))
+	for _, p := range paths {
+		cat, err := bdoapi.Categories().Lookup(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cats = append(cats, cat)
+	}
+	return cats
+}
+
+func selectAll(bdoapi.Category, bdoapi.MarketListObject) bool { return true }
+
+func TestSnapshot(t *testing.T) {
+	m := fakemarket.Demo()
+	c, _ := newBreakerClient(t, m)
+	cats := lookupCategories(t, "material.blood", "material.meat")
+
+	snap, err := c.Snapshot(context.Background(), bdoapi.SnapshotOptions{
+		Categories: cats,
+		Items:      []int64{99999}, // 목록에 없는 아이템
+		Select:     selectAll,
+		SubLists:   true,
+		OrderBooks: true,
+		Workers:    4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := snap.Errors(); len(errs) != 0 {
+		t.Fatalf("errors = %v", errs)
+	}
+	var listed int
+	for _, cat := range cats {
+		list := snap.Lists[cat.Path()]
+		if len(list) == 0 {
+			t.Fatalf("%s: empty list", cat.Path())
+		}
+		listed += len(list)
+		for _, o := range list {
+			it, ok := snap.Item(o.ItemID)
+			if !ok {
+				t.Fatalf("item %d missing", o.ItemID)
+			}
+			if !it.OK() || it.Category != cat.Path() || it.Listing == nil || len(it.SubList) == 0 || it.OrderBook == nil {
+				t.Errorf("item %d = %+v", o.ItemID, it)
+			}
+		}
+	}
+	if len(snap.Items) != listed+1 {
+		t.Errorf("%d items, want %d", len(snap.Items), listed+1)
+	}
+	for i := 1; i < len(snap.Items); i++ {
+		if snap.Items[i-1].ItemID >= snap.Items[i].ItemID {
+			t.Fatal("items not sorted by id")
+		}
+	}
+	// 워커 여러 개여도 아이템마다 한 번씩
+	if n := m.Requests("GetWorldMarketSubList"); n != listed+1 {
+		t.Errorf("%d sub list requests, want %d", n, listed+1)
+	}
+	it, _ := snap.Item(99999)
+	if it.Category != "" || it.Listing != nil || it.OrderBook == nil {
+		t.Errorf("unlisted item = %+v", it)
+	}
+}
+
+func TestSnapshotPartialFailure(t *testing.T) {
+	m := fakemarket.Demo()
+	srv := fakemarket.Start(m)
+	t.Cleanup(srv.Close)
+	c := bdoapi.NewClient(
+		bdoapi.WithBaseURL(srv.URL+"/Trademarket/"),
+		bdoapi.WithRetryPolicy(bdoapi.NoRetry),
+		bdoapi.WithRateLimit(0, 1),
+	)
+	cats := lookupCategories(t, "material.blood", "material.meat")
+	m.InjectFault("GetWorldMarketList", fakemarket.Fault{Status: http.StatusInternalServerError, Times: 1})
+	m.InjectFault("GetWorldMarketSubList", fakemarket.Fault{Status: http.StatusInternalServerError, Times: 1})
+
+	snap, err := c.Snapshot(context.Background(), bdoapi.SnapshotOptions{
+		Categories: cats,
+		Select:     selectAll,
+		SubLists:   true,
+		OrderBooks: true,
+		Workers:    1, // 실패하는 카테고리/아이템이 순서대로 정해짐
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snap.ListErrors) != 1 || snap.ListErrors[0].Stage != "list" || snap.ListErrors[0].Category != cats[0].Path() {
+		t.Fatalf("list errors = %v, want %s", snap.ListErrors, cats[0].Path())
+	}
+	if _, ok := snap.Lists[cats[0].Path()]; ok {
+		t.Errorf("failed category %s has a list", cats[0].Path())
+	}
+	var failed []*bdoapi.SnapshotError
+	for _, it := range snap.Items {
+		if it.Category != cats[1].Path() {
+			t.Errorf("item %d from %q", it.ItemID, it.Category)
+		}
+		if it.OrderBook == nil {
+			t.Errorf("item %d: order book missing", it.ItemID)
+		}
+		failed = append(failed, it.Errors...)
+	}
+	if len(snap.Items) == 0 || len(failed) != 1 {
+		t.Fatalf("item errors = %v, want one", failed)
+	}
+	var se *bdoapi.SnapshotError
+	if !errors.As(failed[0], &se) || se.Stage != "sub_list" || se.ItemID != snap.Items[0].ItemID {
+		t.Errorf("err = %v, want sub_list of item %d", failed[0], snap.Items[0].ItemID)
+	}
+	var st *bdoapi.StatusError
+	if !errors.As(failed[0], &st) || st.StatusCode != http.StatusInternalServerError {
+		t.Errorf("err = %v, want wrapped 500 StatusError", failed[0])
+	}
+}
+
+func TestSnapshotTimeout(t *testing.T) {
+	m := fakemarket.Demo()
+	c, _ := newBreakerClient(t, m)
+	cats := lookupCategories(t, "material.blood")
+	list, err := c.GetMarketListCategory(context.Background(), cats[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetLatency(40 * time.Millisecond)
+
+	begin := time.Now()
+	snap, err := c.Snapshot(context.Background(), bdoapi.SnapshotOptions{
+		Categories: cats,
+		Select:     selectAll,
+		SubLists:   true,
+		Workers:    1,
+		Timeout:    150 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Errorf("returned after %s", elapsed)
+	}
+	if len(snap.Items) != len(list) {
+		t.Fatalf("%d items, want %d", len(snap.Items), len(list))
+	}
+	var done, timedOut int
+	for _, it := range snap.Items {
+		if it.OK() {
+			done++
+			continue
+		}
+		for _, e := range it.Errors {
+			if !errors.Is(e, bdoapi.ErrSnapshotTimeout) {
+				t.Errorf("item %d: err = %v, want ErrSnapshotTimeout", it.ItemID, e)
+			}
+		}
+		timedOut++
+	}
+	if done == 0 || timedOut == 0 {
+		t.Errorf("%d done, %d timed out, want some of each", done, timedOut)
+	}
+
+	t.Run("parent cancel", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := c.Snapshot(ctx, bdoapi.SnapshotOptions{Categories: cats, Select: selectAll, SubLists: true, Workers: 1})
+		if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, bdoapi.ErrSnapshotTimeout) {
+			t.Errorf("err = %v, want deadline exceeded", err)
+		}
+	})
+}
@@ -0,0 +1,246 @@
+package bdoapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+	"time"
+
+	hfm "bdo_calc_go/pkg/huffmanunpack"
+)
+
+/*
+	스트리밍
+	doRequest는 body 전체 → 압축 해제 문자열 → split 순서로 메모리에 다 올림
+	여기서는 huffman 비트스트림을 푸는 대로 '|' 단위 레코드를 하나씩 넘김
+
+	  for m, err := range client.StreamMarketList(ctx, cat) { ... }
+
+	- 재시도는 응답을 여는 단계(헤더 받기 전)까지만, 레코드를 넘기기 시작한 뒤의 에러는 그대로 전달
+	- 루프를 중간에 끊으면 연결을 닫음
+	- body를 읽는 동안에는 http.Client 타임아웃 대신 ctx로만 끊음 (소비 쪽이 느릴 수 있어서)
+*/
+
+// 응답 안의 레코드 하나 ('-'로 구분된 필드들)
+type Record struct {
+	Index int // 응답 안에서 몇 번째인지 (빈 레코드 포함)
+	Raw   string
+}
+
+func (r Record) Fields() []string { return strings.Split(r.Raw, FieldSep) }
+
+// huffman으로 압축된 응답(파일, 녹화본 등)을 레코드 단위로
+func UnpackRecords(endpoint string, packed io.Reader) iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		hr, err := hfm.NewReader(bufio.NewReader(packed))
+		if err != nil {
+			yield(Record{}, &DecodeError{Endpoint: endpoint, Format: "huffman", Err: err})
+			return
+		}
+		splitRecords(endpoint, hr, yield)
+	}
+}
+
+// '|'까지 읽어서 넘김, 빈 레코드는 건너뜀
+func splitRecords(endpoint string, r io.ByteReader, yield func(Record, error) bool) {
+	var (
+		buf []byte
+		idx int
+	)
+	for {
+		b, err := r.ReadByte()
+		if err == io.EOF {
+			if len(buf) > 0 {
+				yield(Record{Index: idx, Raw: string(buf)}, nil)
+			}
+			return
+		}
+		if err != nil {
+			yield(Record{}, &DecodeError{Endpoint: endpoint, Format: "huffman", Err: err})
+			return
+		}
+		if b != RecordSep[0] {
+			buf = append(buf, b)
+			continue
+		}
+		if len(buf) > 0 {
+			if !yield(Record{Index: idx, Raw: string(buf)}, nil) {
+				return
+			}
+			buf = buf[:0]
+		}
+		idx++
+	}
+}
+
+// 레코드 스트림 → T 스트림, 첫 에러에서 멈춤
+func (c *RecordCodec[T]) Seq(endpoint string, records iter.Seq2[Record, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for rec, err := range records {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			v, err := c.DecodeRecord(endpoint, rec.Index, rec.Raw)
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// 응답을 열고 body를 그대로 넘김 (post와 같은 점검/브레이커/재시도 처리)
+func (c *Client) postStream(ctx context.Context, targetAPI string, payload any) (*streamBody, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Available(time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", targetAPI, err)
+	}
+	var gen uint64
+	if c.breaker != nil {
+		if gen, err = c.breaker.Allow(); err != nil {
+			return nil, fmt.Errorf("%s: %w", targetAPI, err)
+		}
+	}
+
+	// 헤더를 받을 때까지만 타임아웃 적용
+	hc := *c.httpClient
+	hc.Timeout = 0
+	sctx, cancel := context.WithCancel(ctx)
+	var timer *time.Timer
+	if c.httpClient.Timeout > 0 {
+		timer = time.AfterFunc(c.httpClient.Timeout, cancel)
+	}
+	resp, err := withRetry(sctx, c, targetAPI, func() (*http.Response, error) {
+		return c.open(sctx, &hc, targetAPI, b)
+	})
+	if timer != nil && !timer.Stop() && err == nil {
+		// 헤더는 받았지만 타이머가 이미 ctx를 취소함
+		resp.Body.Close()
+		err = &TransportError{Endpoint: targetAPI, Err: errors.New("timeout awaiting response headers")}
+	}
+	if err != nil {
+		if ctx.Err() == nil && sctx.Err() != nil {
+			err = &TransportError{Endpoint: targetAPI, Err: errors.New("timeout awaiting response headers")}
+		}
+	}
+	// 헤더를 받은 시점에 바로 기록해서 half-open 시험 슬롯을 돌려줌
+	// (스트림을 읽는 동안 다른 요청이 따로 시험할 수 있고, 그 요청들의 실패를 스트림 성공이 지우지 않음)
+	if c.breaker != nil {
+		c.breaker.Record(gen, err)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &streamBody{ReadCloser: resp.Body, cancel: cancel, gen: gen}, nil
+}
+
+type streamBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+	gen    uint64 // 요청을 시작한 브레이커 세대
+}
+
+func (b *streamBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// huffman 응답을 레코드 단위로
+// 성공은 헤더를 받을 때 이미 기록했으므로, 읽는 도중의 에러만 브레이커에 실패로 더함
+func (c *Client) streamRecords(ctx context.Context, targetAPI string, payload any) iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		body, err := c.postStream(ctx, targetAPI, payload)
+		if err != nil {
+			yield(Record{}, err)
+			return
+		}
+		defer body.Close()
+
+		for rec, err := range c.bodyRecords(targetAPI, body) {
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					err = fmt.Errorf("%s: %w", targetAPI, ctxErr)
+				}
+				if c.breaker != nil && countsAsFailure(err) {
+					c.breaker.Record(body.gen, err)
+				}
+			}
+			if !yield(rec, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// JSON envelope(에러 응답 등)이면 전부 읽어서 decodeBody, 아니면 huffman 스트림
+func (c *Client) bodyRecords(targetAPI string, body io.Reader) iter.Seq2[Record, error] {
+	br := bufio.NewReader(body)
+	head, _ := br.Peek(8)
+	if !looksJSON(head) {
+		return UnpackRecords(targetAPI, br)
+	}
+	return func(yield func(Record, error) bool) {
+		data, err := io.ReadAll(br)
+		if err != nil {
+			yield(Record{}, &TransportError{Endpoint: targetAPI, Err: err})
+			return
+		}
+		s, err := c.decodeBody(targetAPI, data)
+		if err != nil {
+			yield(Record{}, err)
+			return
+		}
+		splitRecords(targetAPI, strings.NewReader(s), yield)
+	}
+}
+
+func StreamMarketList(ctx context.Context, cat Category) iter.Seq2[MarketListObject, error] {
+	return defaultClient.StreamMarketList(ctx, cat)
+}
+
+// GetMarketListCategory의 스트리밍 버전
+func (c *Client) StreamMarketList(ctx context.Context, cat Category) iter.Seq2[MarketListObject, error] {
+	records := c.streamRecords(ctx, "GetWorldMarketList", cat.Payload())
+	return func(yield func(MarketListObject, error) bool) {
+		for m, err := range decodeSeq(c, marketListCodec, "GetWorldMarketList", records) {
+			if err != nil {
+				err = fmt.Errorf("wrong request: [GetWorldMarketList] %s: %w", cat.Path(), err)
+			}
+			if !yield(m, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// SearchMarket의 스트리밍 버전
+func (c *Client) StreamSearch(ctx context.Context, itemIDs ...int) iter.Seq2[MarketListObject, error] {
+	return func(yield func(MarketListObject, error) bool) {
+		if len(itemIDs) == 0 {
+			yield(MarketListObject{}, ErrEmptySearch)
+			return
+		}
+		query := searchQuery(itemIDs)
+		records := c.streamRecords(ctx, "GetWorldMarketSearchList", SearchPayload{SearchResult: query})
+		for m, err := range decodeSeq(c, marketListCodec, "GetWorldMarketSearchList", records) {
+			if err != nil {
+				err = fmt.Errorf("wrong request: [GetWorldMarketSearchList] %s: %w", query, err)
+			}
+			if !yield(m, err) || err != nil {
+				return
+			}
+		}
+	}
+}
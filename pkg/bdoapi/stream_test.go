@@ -0,0 +1,231 @@
+package bdoapi_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"bdo_calc_go/pkg/bdoapi"
+	"bdo_calc_go/pkg/bdoapi/fakemarket"
+	hfm "bdo_calc_go/pkg/huffmanunpack"
+)
+
+func collect(seq iter.Seq2[bdoapi.Record, error]) ([]bdoapi.Record, error) {
+	var out []bdoapi.Record
+	for rec, err := range seq {
+		if err != nil {
+			return out, err
+		}
+		out = append(out, rec)
+	}
+	return out, nil
+}
+
+func TestUnpackRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		packed  []byte
+		want    []bdoapi.Record
+		wantErr bool
+	}{
+		{name: "empty", packed: hfm.Pack("")},
+		{name: "one", packed: hfm.Pack("1-2-3-4|"), want: []bdoapi.Record{{Index: 0, Raw: "1-2-3-4"}}},
+		{name: "no trailing sep", packed: hfm.Pack("1-2|3-4"), want: []bdoapi.Record{{Index: 0, Raw: "1-2"}, {Index: 1, Raw: "3-4"}}},
+		{name: "empty records keep index", packed: hfm.Pack("|1-2||3-4|"), want: []bdoapi.Record{{Index: 1, Raw: "1-2"}, {Index: 3, Raw: "3-4"}}},
+		{name: "only seps", packed: hfm.Pack("|||")},
+		{name: "bad header", packed: []byte{1, 2}, wantErr: true},
+		{name: "truncated", packed: truncate(hfm.Pack(strings.Repeat("6201-0-0-1250|", 50))), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := collect(bdoapi.UnpackRecords("Test", bytes.NewReader(tt.packed)))
+			if tt.wantErr {
+				var de *bdoapi.DecodeError
+				if !errors.As(err, &de) || de.Format != "huffman" {
+					t.Fatalf("err = %v (%T), want huffman DecodeError", err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("record %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func truncate(b []byte) []byte { return b[:len(b)*2/3] }
+
+func TestRecordCodecSeq(t *testing.T) {
+	codec := bdoapi.MustRecordCodec[bdoapi.MarketListObject]()
+	tests := []struct {
+		name    string
+		raw     string
+		want    int
+		wantErr error
+	}{
+		{name: "all good", raw: "1-2-3-4|5-6-7-8|", want: 2},
+		{name: "stops at bad record", raw: "1-2-3-4|5-6|9-9-9-9|", want: 1, wantErr: bdoapi.ErrFieldCount},
+		{name: "bad first", raw: "x-2-3-4|5-6-7-8|", wantErr: strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var n int
+			var err error
+			for _, e := range codec.Seq("Test", bdoapi.UnpackRecords("Test", bytes.NewReader(hfm.Pack(tt.raw)))) {
+				if e != nil {
+					if err != nil {
+						t.Fatal("yielded after an error")
+					}
+					err = e
+					continue
+				}
+				n++
+			}
+			if n != tt.want {
+				t.Errorf("decoded %d, want %d", n, tt.want)
+			}
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStreamMarketListMatchesGet(t *testing.T) {
+	m := fakemarket.Demo()
+	c, _ := newBreakerClient(t, m)
+	cat, err := bdoapi.Categories().Lookup("material.blood")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	want, err := c.GetMarketListCategory(ctx, cat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []bdoapi.MarketListObject
+	for o, err := range c.StreamMarketList(ctx, cat) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, o)
+	}
+	if len(got) == 0 || len(got) != len(want) {
+		t.Fatalf("stream %d records, get %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("record %d: stream %+v, get %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// 응답 앞부분(1/10)만 보내고 멈추는 서버
+// 요청이 끊기면 closed로 알림
+func stallingServer(t *testing.T) (*bdoapi.Client, <-chan struct{}) {
+	t.Helper()
+	packed := hfm.Pack(strings.Repeat("6201-10-20-1250|", 1000))
+	closed := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write(packed[:len(packed)/10])
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+		close(closed)
+	}))
+	t.Cleanup(srv.Close)
+	c := bdoapi.NewClient(
+		bdoapi.WithBaseURL(srv.URL+"/Trademarket/"),
+		bdoapi.WithRetryPolicy(bdoapi.NoRetry),
+		bdoapi.WithRateLimit(0, 1),
+		bdoapi.WithTimeout(50*time.Millisecond),
+	)
+	return c, closed
+}
+
+func waitClosed(t *testing.T, closed <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Error("server still holds the connection")
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	cat, err := bdoapi.Categories().Lookup("material.blood")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("cancel mid stream", func(t *testing.T) {
+		c, closed := stallingServer(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var n int
+		var last error
+		for _, err := range c.StreamMarketList(ctx, cat) {
+			if err != nil {
+				last = err
+				break
+			}
+			// 헤더 타임아웃(50ms)이 지나도 body는 ctx로만 끊김
+			if n++; n == 1 {
+				time.AfterFunc(100*time.Millisecond, cancel)
+			}
+		}
+		if n == 0 {
+			t.Fatal("no records before cancel")
+		}
+		if !errors.Is(last, context.Canceled) {
+			t.Errorf("err = %v, want context canceled", last)
+		}
+		waitClosed(t, closed)
+	})
+
+	t.Run("break closes connection", func(t *testing.T) {
+		c, closed := stallingServer(t)
+		for _, err := range c.StreamMarketList(context.Background(), cat) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			break
+		}
+		waitClosed(t, closed)
+	})
+
+	t.Run("cancel before headers", func(t *testing.T) {
+		m := fakemarket.Demo()
+		m.SetLatency(time.Second)
+		c, _ := newBreakerClient(t, m)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		begin := time.Now()
+		var last error
+		for _, err := range c.StreamMarketList(ctx, cat) {
+			last = err
+		}
+		if !errors.Is(last, context.DeadlineExceeded) {
+			t.Errorf("err = %v, want deadline exceeded", last)
+		}
+		if elapsed := time.Since(begin); elapsed > 500*time.Millisecond {
+			t.Errorf("returned after %s", elapsed)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package bdoapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// 등록 대기(거래소 올라가기 전) 매물
+// id-강화레벨-가격-등록시각
+type WaitListObject struct {
+	ItemID      int64     `json:"item_id" rec:"0"`
+	Enhancement int8      `json:"enhancement" rec:"1"`
+	Price       int64     `json:"price" rec:"2"`
+	LiveAt      time.Time `json:"live_at" rec:"3,unix"` // 거래소에 풀리는 시각, 지역 시간대
+}
+
+var waitListCodec = MustRecordCodec[WaitListObject]()
+
+func GetWaitList(ctx context.Context) ([]WaitListObject, error) {
+	return defaultClient.GetWaitList(ctx)
+}
+
+func (c *Client) GetWaitList(ctx context.Context) ([]WaitListObject, error) {
+	resultMsg, err := doRequest(ctx, c, "GetWorldMarketWaitList", EmptyPayload{})
+	if err != nil {
+		return nil, fmt.Errorf("wrong request: [GetWorldMarketWaitList]: %w", err)
+	}
+
+	// 대기 매물이 없으면 resultMsg가 "0"으로 옴
+	if resultMsg == "0" {
+		return nil, nil
+	}
+	return decodeAll(c, waitListCodec.In(c.region.Location), "GetWorldMarketWaitList", resultMsg)
+}
@@ -2,6 +2,7 @@
 package huffmanunpack
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/binary"
 	"errors"
@@ -223,3 +224,103 @@ func UnpackFromReader(r io.Reader) (string, error) {
 func UnpackBytes(b []byte) (string, error) { return UnpackFromReader(bytes.NewReader(b)) }
 
 // out, err := unpackBytes(test)
+
+/*** ---------- 스트리밍 디코더 ---------- ***/
+// 헤더(빈도표)만 먼저 읽고, 본문은 ReadByte 할 때마다 필요한 만큼만 읽어요.
+type Reader struct {
+	tree *Node
+	src  io.ByteReader
+	bits int  // 남은 비트 수
+	cur  byte // 읽던 바이트
+	nbit int  // cur에 남은 비트 수
+}
+
+func NewReader(r io.Reader) (*Reader, error) {
+	entries, err := getFreqsOrdered(r)
+	if err != nil {
+		return nil, err
+	}
+	tree := makeTreeOrdered(entries)
+	if tree == nil {
+		return nil, errors.New("empty frequency table")
+	}
+	packedBits, err := readU32(r)
+	if err != nil {
+		return nil, err
+	}
+	packedBytes, err := readU32(r)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := readU32(r); err != nil { // unpackedBytes
+		return nil, err
+	}
+	src := bufio.NewReader(io.LimitReader(r, int64(packedBytes)))
+	return &Reader{tree: tree, src: src, bits: int(packedBits)}, nil
+}
+
+func (r *Reader) readBit() (bool, error) {
+	if r.bits <= 0 {
+		return false, io.EOF
+	}
+	if r.nbit == 0 {
+		b, err := r.src.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return false, err
+		}
+		r.cur, r.nbit = b, 8
+	}
+	r.nbit--
+	r.bits--
+	return (r.cur>>uint(r.nbit))&1 == 1, nil // MSB-first
+}
+
+// 다음 글자 하나, 비트를 다 쓰면 io.EOF
+func (r *Reader) ReadByte() (byte, error) {
+	if r.bits <= 0 {
+		return 0, io.EOF
+	}
+	n := r.tree
+	if n.left == nil && n.right == nil {
+		// 글자가 한 종류뿐인 트리, 글자당 1비트로 봄
+		if _, err := r.readBit(); err != nil {
+			return 0, err
+		}
+		return n.c, nil
+	}
+	for n.left != nil || n.right != nil {
+		bit, err := r.readBit()
+		if err != nil {
+			if err == io.EOF {
+				err = errors.New("invalid tree/bitstream: truncated symbol")
+			}
+			return 0, err
+		}
+		if bit {
+			n = n.right
+		} else {
+			n = n.left
+		}
+		if n == nil {
+			return 0, errors.New("invalid tree: dead end")
+		}
+	}
+	return n.c, nil
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	for i := range p {
+		c, err := r.ReadByte()
+		if err != nil {
+			if err == io.EOF && i > 0 {
+				return i, nil
+			}
+			return i, err
+		}
+		p[i] = c
+	}
+	return len(p), nil
+}
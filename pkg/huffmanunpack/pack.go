@@ -0,0 +1,80 @@
+package huffmanunpack
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+/*** ---------- 압축 (테스트 서버/녹화 데이터용) ---------- ***/
+// UnpackBytes가 만드는 것과 같은 트리를 쓰도록 빈도표 순서를 그대로 헤더에 기록해요.
+// 거래소가 쓰는 인코더와 바이트 단위로 같지는 않지만, 이 패키지(와 파이썬 구현)로는 풀려요.
+func Pack(s string) []byte {
+	data := []byte(s)
+
+	// 처음 나온 순서대로 빈도표
+	var entries []freqEntry
+	index := map[byte]int{}
+	for _, c := range data {
+		i, ok := index[c]
+		if !ok {
+			i = len(entries)
+			index[c] = i
+			entries = append(entries, freqEntry{c: c})
+		}
+		entries[i].f++
+	}
+	// 글자가 한 종류 이하면 트리가 잎 하나라 비트를 못 씀 → 빈도 0짜리 더미 추가
+	for len(entries) < 2 {
+		var dummy byte
+		for {
+			if _, ok := index[dummy]; !ok {
+				break
+			}
+			dummy++
+		}
+		index[dummy] = len(entries)
+		entries = append(entries, freqEntry{c: dummy})
+	}
+
+	codes := map[byte][]bool{}
+	var walk func(n *Node, prefix []bool)
+	walk = func(n *Node, prefix []bool) {
+		if n.left == nil && n.right == nil {
+			codes[n.c] = append([]bool(nil), prefix...)
+			return
+		}
+		walk(n.left, append(prefix, false))
+		walk(n.right, append(prefix, true))
+	}
+	walk(makeTreeOrdered(entries), nil)
+
+	var packed []byte
+	bits := 0
+	for _, c := range data {
+		for _, bit := range codes[c] {
+			if bits%8 == 0 {
+				packed = append(packed, 0)
+			}
+			if bit {
+				packed[bits/8] |= 1 << (7 - uint(bits%8)) // MSB-first
+			}
+			bits++
+		}
+	}
+
+	var buf bytes.Buffer
+	u32 := func(v uint32) { _ = binary.Write(&buf, binary.LittleEndian, v) }
+	total := 4*3 + 8*len(entries) + 4*3 + len(packed)
+	u32(uint32(total)) // file_len
+	u32(0)             // always0
+	u32(uint32(len(entries)))
+	for _, e := range entries {
+		u32(e.f)
+		buf.Write([]byte{e.c, 0, 0, 0})
+	}
+	u32(uint32(bits))
+	u32(uint32(len(packed)))
+	u32(uint32(len(data)))
+	buf.Write(packed)
+	return buf.Bytes()
+}
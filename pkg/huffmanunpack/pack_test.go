@@ -0,0 +1,63 @@
+package huffmanunpack
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPackRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ""},
+		{"one char", "a"},
+		{"one symbol repeated", strings.Repeat("7", 100)},
+		{"two symbols", "0-1-0-1"},
+		{"record", "6201-0-0-1250-8123-41000000-875-1625-1240-1772452620|"},
+		{"many records", strings.Repeat("6201-0-0-1250-8123-41000000|6202-0-0-980-0-12500000|", 200)},
+		{"all bytes", allBytes()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packed := Pack(tt.in)
+
+			got, err := UnpackBytes(packed)
+			if err != nil {
+				t.Fatalf("UnpackBytes: %v", err)
+			}
+			if got != tt.in {
+				t.Errorf("UnpackBytes = %q, want %q", trim(got), trim(tt.in))
+			}
+
+			r, err := NewReader(bytes.NewReader(packed))
+			if err != nil {
+				t.Fatalf("NewReader: %v", err)
+			}
+			b, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("Reader: %v", err)
+			}
+			if string(b) != tt.in {
+				t.Errorf("Reader = %q, want %q", trim(string(b)), trim(tt.in))
+			}
+		})
+	}
+}
+
+func allBytes() string {
+	b := make([]byte, 256)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return string(b)
+}
+
+func trim(s string) string {
+	if len(s) > 64 {
+		return s[:64] + "..."
+	}
+	return s
+}
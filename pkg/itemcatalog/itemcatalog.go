@@ -0,0 +1,288 @@
+package itemcatalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+var ErrNotFound = errors.New("item not found")
+
+// 아이템 기본 정보, items.item_attrs(jsonb)에 그대로 저장
+type Item struct {
+	ID           int               `json:"id"`
+	Names        map[string]string `json:"names"` // 언어 코드 → 이름 (ko, en, ...)
+	MainCategory int               `json:"main_category"`
+	SubCategory  int               `json:"sub_category"`
+	Grade        int               `json:"grade"` // 0 흰색 ~ 4 주황
+	MaxStack     int               `json:"max_stack"`
+	Weight       float64           `json:"weight"` // LT
+	MinEnhance   int               `json:"min_enhance"`
+	MaxEnhance   int               `json:"max_enhance"`
+}
+
+// lang 이름이 없으면 ko → en 순으로 대체
+func (it *Item) Name(lang string) string {
+	for _, l := range []string{lang, "ko", "en"} {
+		if n := it.Names[l]; n != "" {
+			return n
+		}
+	}
+	return strconv.Itoa(it.ID)
+}
+
+// 읽기 위주, 여러 고루틴에서 공유 가능
+type Catalog struct {
+	mu   sync.RWMutex
+	byID map[int]*Item
+}
+
+func New(items ...*Item) *Catalog {
+	c := &Catalog{byID: make(map[int]*Item, len(items))}
+	c.Add(items...)
+	return c
+}
+
+// 같은 id는 덮어씀
+func (c *Catalog) Add(items ...*Item) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, it := range items {
+		c.byID[it.ID] = it
+	}
+}
+
+// 전체를 새로 만든 인덱스로 교체 (DB에서 지워진 아이템도 빠짐)
+// 인덱스는 락 밖에서 만들고 바꿔 끼우는 동안만 잠금
+func (c *Catalog) Replace(items ...*Item) {
+	byID := make(map[int]*Item, len(items))
+	for _, it := range items {
+		byID[it.ID] = it
+	}
+	c.mu.Lock()
+	c.byID = byID
+	c.mu.Unlock()
+}
+
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.byID)
+}
+
+func (c *Catalog) Get(id int) (*Item, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	it, ok := c.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	return it, nil
+}
+
+// id 순
+func (c *Catalog) Items() []*Item {
+	c.mu.RLock()
+	out := make([]*Item, 0, len(c.byID))
+	for _, it := range c.byID {
+		out = append(out, it)
+	}
+	c.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// json 덤프: Item 배열, id가 없는(0 이하) 항목이 있으면 에러
+func LoadJSON(r io.Reader) ([]*Item, error) {
+	var items []*Item
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("item catalog json: %w", err)
+	}
+	for i, it := range items {
+		if it == nil || it.ID <= 0 {
+			return nil, fmt.Errorf("item catalog json: item %d: missing id", i)
+		}
+	}
+	return items, nil
+}
+
+// csv 덤프, 첫 줄은 헤더
+// id,name_ko,name_en,main_category,sub_category,grade,max_stack,weight,min_enhance,max_enhance
+// name_xx 컬럼은 언어별로 더 추가 가능, 없는 숫자 컬럼은 0 (id는 필수, 비어 있으면 에러)
+func LoadCSV(r io.Reader) ([]*Item, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("item catalog csv header: %w", err)
+	}
+	col := map[string]int{}
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := col["id"]; !ok {
+		return nil, errors.New("item catalog csv: missing id column")
+	}
+
+	var items []*Item
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// csv.ParseError에 줄 번호가 있음
+			return nil, fmt.Errorf("item catalog csv: %w", err)
+		}
+		// 따옴표 안 줄바꿈이 있어도 실제 파일의 줄 번호
+		line, _ := cr.FieldPos(0)
+		get := func(name string) string {
+			if i, ok := col[name]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		atoi := func(name string) (int, error) {
+			v := get(name)
+			if v == "" {
+				return 0, nil
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, fmt.Errorf("item catalog csv line %d: %s: %w", line, name, err)
+			}
+			return n, nil
+		}
+
+		it := &Item{Names: map[string]string{}}
+		for name, ptr := range map[string]*int{
+			"id": &it.ID, "main_category": &it.MainCategory, "sub_category": &it.SubCategory, "grade": &it.Grade,
+			"max_stack": &it.MaxStack, "min_enhance": &it.MinEnhance, "max_enhance": &it.MaxEnhance,
+		} {
+			if *ptr, err = atoi(name); err != nil {
+				return nil, err
+			}
+		}
+		if it.ID <= 0 {
+			return nil, fmt.Errorf("item catalog csv line %d: bad id %q", line, get("id"))
+		}
+		if w := get("weight"); w != "" {
+			if it.Weight, err = strconv.ParseFloat(w, 64); err != nil {
+				return nil, fmt.Errorf("item catalog csv line %d: weight: %w", line, err)
+			}
+		}
+		for h := range col {
+			if lang, ok := strings.CutPrefix(h, "name_"); ok && get(h) != "" {
+				it.Names[lang] = get(h)
+			}
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// 파일 확장자로 형식 결정 (.json / .csv)
+func Load(r io.Reader, format string) ([]*Item, error) {
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case "json":
+		return LoadJSON(r)
+	case "csv":
+		return LoadCSV(r)
+	}
+	return nil, fmt.Errorf("item catalog: unknown format %q", format)
+}
+
+/* ---------- 이름 검색 ---------- */
+
+type Match struct {
+	Item  *Item   `json:"item"`
+	Score float64 `json:"score"` // 0~1
+}
+
+// 이 점수 미만은 버림
+const minScore = 0.5
+
+// 공백/대소문자 무시, 완전일치 > 접두 > 포함 > 편집거리 순으로 점수
+func (c *Catalog) Search(query string, limit int) []Match {
+	q := normalize(query)
+	if q == "" {
+		return nil
+	}
+
+	c.mu.RLock()
+	out := make([]Match, 0)
+	for _, it := range c.byID {
+		best := 0.0
+		for _, n := range it.Names {
+			if s := score(normalize(n), q); s > best {
+				best = s
+			}
+		}
+		if best >= minScore {
+			out = append(out, Match{Item: it, Score: best})
+		}
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Item.ID < out[j].Item.ID
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if !unicode.IsSpace(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func score(name, q string) float64 {
+	switch {
+	case name == q:
+		return 1
+	case strings.HasPrefix(name, q):
+		return 0.9
+	case strings.Contains(name, q):
+		return 0.8
+	}
+	a, b := []rune(name), []rune(q)
+	d := levenshtein(a, b)
+	return 0.7 * (1 - float64(d)/float64(max(len(a), len(b))))
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
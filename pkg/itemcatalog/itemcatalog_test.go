@@ -0,0 +1,158 @@
+package itemcatalog_test
+
+import (
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+
+	"bdo_calc_go/pkg/itemcatalog"
+)
+
+func TestLoadCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		want    []itemcatalog.Item
+		wantErr string
+	}{
+		{
+			name: "all columns",
+			csv: "id,name_ko,name_en,main_category,sub_category,grade,max_stack,weight,min_enhance,max_enhance\n" +
+				"6201,사슴 피,Deer Blood,25,8,1,9999,0.1,0,0\n",
+			want: []itemcatalog.Item{{ID: 6201, Names: map[string]string{"ko": "사슴 피", "en": "Deer Blood"}, MainCategory: 25, SubCategory: 8, Grade: 1, MaxStack: 9999, Weight: 0.1}},
+		},
+		{
+			name: "header case and order, missing columns are zero",
+			csv:  " Name_JA , ID\nシカの血,6201\n,6202\n",
+			want: []itemcatalog.Item{{ID: 6201, Names: map[string]string{"ja": "シカの血"}}, {ID: 6202, Names: map[string]string{}}},
+		},
+		{
+			name: "quoted newline",
+			csv:  "id,name_en\n1,\"a\nb\"\n2,c\n",
+			want: []itemcatalog.Item{{ID: 1, Names: map[string]string{"en": "a\nb"}}, {ID: 2, Names: map[string]string{"en": "c"}}},
+		},
+		{name: "header only", csv: "id,name_ko\n"},
+		{name: "empty", csv: "", wantErr: "csv header"},
+		{name: "no id column", csv: "name_ko\n사슴 피\n", wantErr: "missing id column"},
+		{name: "empty id", csv: "id,name_ko\n6201,a\n,b\n", wantErr: `line 3: bad id ""`},
+		{name: "zero id", csv: "id,name_ko\n0,a\n", wantErr: `line 2: bad id "0"`},
+		// 따옴표 안 줄바꿈 다음 줄
+		{name: "empty id after quoted newline", csv: "id,name_en\n1,\"a\nb\"\n,c\n", wantErr: `line 4: bad id ""`},
+		{name: "bad number", csv: "id,grade\n6201,x\n", wantErr: "line 2: grade"},
+		{name: "bad weight", csv: "id,weight\n6201,1kg\n", wantErr: "line 2: weight"},
+		{name: "bad quote", csv: "id,name_en\n1,\"a\n", wantErr: "line"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := itemcatalog.LoadCSV(strings.NewReader(tt.csv))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkItems(t, got, tt.want)
+		})
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    []itemcatalog.Item
+		wantErr string
+	}{
+		{
+			name: "items",
+			json: `[{"id":6201,"names":{"ko":"사슴 피"},"grade":1,"weight":0.1},{"id":6202,"max_enhance":5}]`,
+			want: []itemcatalog.Item{{ID: 6201, Names: map[string]string{"ko": "사슴 피"}, Grade: 1, Weight: 0.1}, {ID: 6202, MaxEnhance: 5}},
+		},
+		{name: "empty array", json: `[]`},
+		{name: "not an array", json: `{"id":1}`, wantErr: "item catalog json"},
+		{name: "missing id", json: `[{"id":1},{"names":{"ko":"a"}}]`, wantErr: "item 1: missing id"},
+		{name: "null item", json: `[null]`, wantErr: "item 0: missing id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := itemcatalog.LoadJSON(strings.NewReader(tt.json))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkItems(t, got, tt.want)
+		})
+	}
+}
+
+func checkItems(t *testing.T, got []*itemcatalog.Item, want []itemcatalog.Item) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%d items, want %d", len(got), len(want))
+	}
+	for i := range got {
+		g, w := *got[i], want[i]
+		if !maps.Equal(g.Names, w.Names) {
+			t.Errorf("item %d names = %v, want %v", i, g.Names, w.Names)
+		}
+		g.Names, w.Names = nil, nil
+		if !reflect.DeepEqual(g, w) {
+			t.Errorf("item %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	c := itemcatalog.New(
+		&itemcatalog.Item{ID: 1, Names: map[string]string{"ko": "사슴 피", "en": "Deer Blood"}},
+		&itemcatalog.Item{ID: 2, Names: map[string]string{"ko": "늑대 피", "en": "Wolf Blood"}},
+		&itemcatalog.Item{ID: 3, Names: map[string]string{"ko": "사슴 고기", "en": "Deer Meat"}},
+		&itemcatalog.Item{ID: 4, Names: map[string]string{"en": "Blood of the Deer"}},
+		&itemcatalog.Item{ID: 5, Names: map[string]string{"en": "Deer"}},
+	)
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []int // 점수 순 id
+	}{
+		// 완전일치 > 접두 > 포함, 같은 점수는 id 순
+		{name: "exact, prefix, contains", query: "deer", want: []int{5, 1, 3, 4}},
+		{name: "ignores case and spaces", query: "DEERBLOOD", want: []int{1}},
+		{name: "any language", query: "사슴", want: []int{1, 3}},
+		{name: "prefix beats contains", query: "blood", want: []int{4, 1, 2}},
+		{name: "typo", query: "deer blod", want: []int{1}},
+		{name: "limit", query: "blood", limit: 2, want: []int{4, 1}},
+		{name: "too far", query: "hammer"},
+		{name: "blank", query: "  "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.Search(tt.query, tt.limit)
+			ids := make([]int, len(got))
+			for i, m := range got {
+				ids[i] = m.Item.ID
+				if i > 0 && m.Score > got[i-1].Score {
+					t.Errorf("not sorted by score: %v", got)
+				}
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("Search(%q) = %v, want %v", tt.query, ids, tt.want)
+				}
+			}
+		})
+	}
+}